@@ -0,0 +1,325 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const _esc = 0x1b
+
+// VisibleWidth returns the number of terminal cells that str occupies when
+// printed, ignoring any escape sequences it contains.
+func VisibleWidth(str string) int {
+	var n int
+	for i := 0; i < len(str); {
+		if str[i] == _esc {
+			i += escapeLen(str[i:])
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(str[i:])
+		n += RuneWidth(r)
+		i += size
+	}
+	return n
+}
+
+// Strip returns str with all escape sequences removed.
+func Strip(str string) string {
+	if strings.IndexByte(str, _esc) < 0 {
+		return str
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(str))
+	for i := 0; i < len(str); {
+		if str[i] == _esc {
+			i += escapeLen(str[i:])
+			continue
+		}
+		buf.WriteByte(str[i])
+		i++
+	}
+	return buf.String()
+}
+
+// WrapNested returns str wrapped in s, as s.Wrap does, but also re-applies s
+// after each reset within str, so that nested styled text (e.g. a styled
+// table cell) does not cancel s for the remainder of str.
+func WrapNested(s Style, str string) string {
+	esc := s.Escape()
+	if len(esc) == 0 || strings.IndexByte(str, _esc) < 0 {
+		return s.Wrap(str)
+	}
+
+	var buf strings.Builder
+	buf.WriteString(esc)
+	for i := 0; i < len(str); {
+		if str[i] != _esc {
+			buf.WriteByte(str[i])
+			i++
+			continue
+		}
+
+		n := escapeLen(str[i:])
+		seq := str[i : i+n]
+		i += n
+
+		if !IsSGR(seq) {
+			buf.WriteString(seq)
+			continue
+		}
+
+		switch params := seq[2 : len(seq)-1]; {
+		case params == "" || params == "0":
+			buf.WriteString(seq + esc)
+		case strings.HasPrefix(params, "0;"):
+			buf.WriteString(Reset.String() + esc + "\x1b[" + params[2:] + "m")
+		default:
+			buf.WriteString(seq)
+		}
+	}
+	buf.WriteString(s.Reset())
+	return buf.String()
+}
+
+// Truncate shortens str so that it occupies at most width visible cells,
+// replacing the removed text with tail. Escape sequences do not count towards
+// width, and any style that is active at the truncation point is reset after
+// tail.
+func Truncate(str string, width int, tail string) string {
+	if VisibleWidth(str) <= width {
+		return str
+	}
+
+	limit := width - VisibleWidth(tail)
+	if limit < 0 {
+		return Truncate(tail, width, "")
+	}
+
+	var (
+		buf   strings.Builder
+		state sgrState
+		col   int
+	)
+
+	for i := 0; i < len(str); {
+		if str[i] == _esc {
+			n := escapeLen(str[i:])
+			buf.WriteString(str[i : i+n])
+			state.update(str[i : i+n])
+			i += n
+			continue
+		}
+
+		r, n := utf8.DecodeRuneInString(str[i:])
+		if col+RuneWidth(r) > limit {
+			break
+		}
+		buf.WriteString(str[i : i+n])
+		col += RuneWidth(r)
+		i += n
+	}
+
+	buf.WriteString(tail)
+	buf.WriteString(state.close())
+	return buf.String()
+}
+
+// EscapeLen returns the length of the escape sequence at the start of str,
+// which must begin with ESC, and whether the sequence is complete. CSI
+// sequences end at their final byte; OSC sequences end at BEL or ST; DCS, SOS,
+// PM, and APC sequences end at ST; and any other sequence is two bytes long.
+// An incomplete sequence consumes the remainder of str.
+func EscapeLen[S ~string | ~[]byte](str S) (n int, complete bool) {
+	if len(str) < 2 {
+		return len(str), false
+	}
+
+	switch str[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes followed by a final byte.
+		for i := 2; i < len(str); i++ {
+			if c := str[i]; c >= 0x40 && c <= 0x7e {
+				return i + 1, true
+			}
+		}
+	case ']', 'P', 'X', '^', '_':
+		// OSC, DCS, SOS, PM, APC: terminated by ST (or BEL for OSC).
+		for i := 2; i < len(str); i++ {
+			switch str[i] {
+			case 0x07:
+				if str[1] == ']' {
+					return i + 1, true
+				}
+			case _esc:
+				if i+1 < len(str) && str[i+1] == '\\' {
+					return i + 2, true
+				}
+			}
+		}
+	default:
+		return 2, true
+	}
+
+	return len(str), false
+}
+
+// escapeLen returns the length of the escape sequence at the start of str
+// (see [EscapeLen]).
+func escapeLen(str string) int {
+	n, _ := EscapeLen(str)
+	return n
+}
+
+// IsSGR reports whether seq is a Select Graphic Rendition sequence, such as
+// "\x1b[1;31m".
+func IsSGR(seq string) bool {
+	return len(seq) >= 3 && seq[0] == _esc && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+// sgrState tracks the SGR attributes and colors that are active at a point in
+// a string, so that they can be closed and re-opened around inserted line
+// breaks. A code replaces any earlier code that sets the same attribute, so
+// the state stays small however many sequences it sees.
+type sgrState struct {
+	// active holds the parameters of each active code, in the order they
+	// were set, e.g. ["1", "38;5;208"].
+	active []sgrCode
+}
+
+type sgrCode struct {
+	slot   int
+	params string
+}
+
+// update applies seq to s if it is an SGR sequence.
+func (s *sgrState) update(seq string) {
+	if !IsSGR(seq) {
+		return
+	}
+
+	params := strings.Split(seq[2:len(seq)-1], ";")
+	for i := 0; i < len(params); i++ {
+		// Subparameters are separated by colons, e.g. "38:5:208" or "4:3",
+		// and belong to the same parameter.
+		head, _, sub := strings.Cut(params[i], ":")
+		code, _ := strconv.Atoi(head)
+
+		n := 1
+		if !sub && (code == 38 || code == 48 || code == 58) {
+			_, used, ok := ParseExtendedColor(params[i+1:])
+			if !ok {
+				// An invalid color makes the terminal ignore the rest of
+				// the sequence.
+				return
+			}
+			n += used
+		}
+
+		slot, set := sgrSlot(code)
+		switch {
+		case code == 0:
+			s.active = s.active[:0]
+		case code == 22:
+			s.clear(1)
+			s.clear(2)
+		case !set:
+			s.clear(slot)
+		default:
+			s.clear(slot)
+			s.active = append(s.active, sgrCode{
+				slot:   slot,
+				params: strings.Join(params[i:i+n], ";"),
+			})
+		}
+		i += n - 1
+	}
+}
+
+// clear removes the code that sets slot, if any.
+func (s *sgrState) clear(slot int) {
+	for i, code := range s.active {
+		if code.slot == slot {
+			s.active = append(s.active[:i], s.active[i+1:]...)
+			return
+		}
+	}
+}
+
+// open returns the sequence needed to re-establish s.
+func (s *sgrState) open() string {
+	if len(s.active) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.WriteString("\x1b[")
+	for i, code := range s.active {
+		if i > 0 {
+			buf.WriteByte(';')
+		}
+		buf.WriteString(code.params)
+	}
+	buf.WriteByte('m')
+	return buf.String()
+}
+
+// close returns the sequence needed to reset s, if any.
+func (s *sgrState) close() string {
+	if len(s.active) == 0 {
+		return ""
+	}
+	return Reset.String()
+}
+
+// sgrSlot returns the attribute that an SGR code sets or, if set is false,
+// resets. Codes with the same slot override each other: for example, 31 and
+// 38;5;208 both set the foreground color, and 39 resets it.
+func sgrSlot(code int) (slot int, set bool) {
+	switch {
+	case code == 4 || code == 21:
+		return 4, true
+	case code == 5 || code == 6:
+		return 5, true
+	case code >= 10 && code <= 19:
+		return 10, true
+	case code >= 23 && code <= 29:
+		return code - 20, false
+	case code >= 30 && code <= 38, code >= 90 && code <= 97:
+		return 38, true
+	case code == 39:
+		return 38, false
+	case code >= 40 && code <= 48, code >= 100 && code <= 107:
+		return 48, true
+	case code == 49:
+		return 48, false
+	case code == 55:
+		return 53, false
+	case code == 59:
+		return 58, false
+	default:
+		return code, true
+	}
+}
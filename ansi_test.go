@@ -0,0 +1,194 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	cases := map[string]int{
+		"":                              0,
+		"foo":                           3,
+		FgRed.Wrap("foo"):               3,
+		Combine(Bold, FgRed).Wrap("世界"): 4,
+		"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\": 4,
+		"\x1b]0;title\x07x": 1,
+		"\x1b[2Kx":          1,
+		"\x1b[31":           0,
+	}
+
+	for str, want := range cases {
+		require.Equal(t, want, VisibleWidth(str), "%q", str)
+	}
+}
+
+func TestStrip(t *testing.T) {
+	require.Equal(t, "foo", Strip("foo"))
+	require.Equal(t, "foo bar", Strip(FgRed.Wrap("foo")+" "+Bold.Wrap("bar")))
+	require.Equal(t, "link", Strip("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\"))
+}
+
+func TestSGRState(t *testing.T) {
+	var state sgrState
+	require.Equal(t, "", state.open())
+	require.Equal(t, "", state.close())
+
+	state.update(FgRed.String())
+	state.update("\x1b[2K")
+	state.update(Bold.String())
+	require.Equal(t, "\x1b[31;1m", state.open())
+	require.Equal(t, Reset.String(), state.close())
+
+	state.update("\x1b[0;32m")
+	require.Equal(t, "\x1b[32m", state.open())
+
+	state.update("\x1b[m")
+	require.Equal(t, "", state.open())
+	require.Equal(t, "", state.close())
+
+	// Codes replace earlier codes that set the same attribute.
+	for i := 0; i < 100; i++ {
+		state.update(Fg256(uint8(i)).String())
+		state.update(Bg256(uint8(i)).String())
+		state.update(Bold.String())
+	}
+	require.Equal(t, "\x1b[38;5;99;48;5;99;1m", state.open())
+
+	state.update("\x1b[4;9;2m")
+	state.update("\x1b[39;22;29m")
+	require.Equal(t, "\x1b[48;5;99;4m", state.open())
+
+	state.update("\x1b[38:2:1:2:3;44m")
+	require.Equal(t, "\x1b[4;38:2:1:2:3;44m", state.open())
+
+	state.update("\x1b[24;49;39m")
+	require.Equal(t, "", state.open())
+
+	// An invalid color discards the rest of the sequence.
+	state.update("\x1b[1;38;5;300;3m")
+	require.Equal(t, "\x1b[1m", state.open())
+}
+
+func TestTruncate(t *testing.T) {
+	var (
+		red   = FgRed.String()
+		reset = Reset.String()
+	)
+
+	require.Equal(t, "foo", Truncate("foo", 3, "…"))
+	require.Equal(t, "fo…", Truncate("foobar", 3, "…"))
+	require.Equal(t, "..", Truncate("foobar", 2, "..."))
+	require.Equal(t, "世…", Truncate("世界", 3, "…"))
+	require.Equal(
+		t,
+		red+"foo"+"…"+reset,
+		Truncate(red+"foobar"+reset, 4, "…"),
+	)
+	require.Equal(
+		t,
+		"ab"+red+"…"+reset,
+		Truncate("ab"+red+"cd"+reset, 3, "…"),
+	)
+}
+
+func TestWrapNested(t *testing.T) {
+	var (
+		bg    = BgBlue.String()
+		reset = Reset.String()
+	)
+
+	cases := map[string]struct {
+		give string
+		want string
+	}{
+		"plain": {
+			give: "abc",
+			want: bg + "abc" + reset,
+		},
+		"reset": {
+			give: "a" + FgRed.Wrap("b") + "c",
+			want: bg + "a" + FgRed.String() + "b" + reset + bg + "c" + reset,
+		},
+		"short reset": {
+			give: "a\x1b[mb",
+			want: bg + "a\x1b[m" + bg + "b" + reset,
+		},
+		"reset and set": {
+			give: "a\x1b[0;1mb",
+			want: bg + "a" + reset + bg + "\x1b[1mb" + reset,
+		},
+		"other": {
+			give: "a\x1b[1mb\x1b[2Kc",
+			want: bg + "a\x1b[1mb\x1b[2Kc" + reset,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, WrapNested(BgBlue, tt.give))
+		})
+	}
+
+	t.Cleanup(OverrideEnabled(false))
+	require.Equal(t, "a"+FgRed.String()+"b", WrapNested(BgBlue, "a"+FgRed.String()+"b"))
+}
+
+func TestEscapeLen(t *testing.T) {
+	cases := map[string]struct {
+		give     string
+		want     int
+		complete bool
+	}{
+		"csi":         {give: "\x1b[1;31mx", want: 7, complete: true},
+		"csi partial": {give: "\x1b[1;3", want: 5},
+		"osc bel":     {give: "\x1b]0;title\ax", want: 10, complete: true},
+		"osc st":      {give: "\x1b]8;;url\x1b\\x", want: 10, complete: true},
+		"osc partial": {give: "\x1b]0;ti", want: 6},
+		"dcs bel":     {give: "\x1bPq\a\x1b\\x", want: 6, complete: true},
+		"apc partial": {give: "\x1b_x\x1b", want: 4},
+		"two byte":    {give: "\x1b7x", want: 2, complete: true},
+		"esc":         {give: "\x1b", want: 1},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, complete := EscapeLen(tt.give)
+			require.Equal(t, tt.want, n)
+			require.Equal(t, tt.complete, complete)
+
+			n, complete = EscapeLen([]byte(tt.give))
+			require.Equal(t, tt.want, n)
+			require.Equal(t, tt.complete, complete)
+		})
+	}
+}
+
+func TestIsSGR(t *testing.T) {
+	require.True(t, IsSGR("\x1b[m"))
+	require.True(t, IsSGR(FgRed.String()))
+	require.True(t, IsSGR(Fg256(208).String()))
+	require.False(t, IsSGR("\x1b[2K"))
+	require.False(t, IsSGR("x[1m"))
+	require.False(t, IsSGR("\x1b]m"))
+}
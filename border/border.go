@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package border provides character sets for drawing borders.
+package border
+
+// A Set is a set of strings used to draw a border. Each string should occupy
+// a single terminal cell. Junctions are used when a border is subdivided, e.g.
+// between the cells of a table.
+type Set struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	TopT        string
+	BottomT     string
+	LeftT       string
+	RightT      string
+	Cross       string
+}
+
+// Border sets.
+var (
+	// None draws no border.
+	None = Set{}
+
+	// ASCII draws a border using only ASCII characters.
+	ASCII = Set{
+		Horizontal:  "-",
+		Vertical:    "|",
+		TopLeft:     "+",
+		TopRight:    "+",
+		BottomLeft:  "+",
+		BottomRight: "+",
+		TopT:        "+",
+		BottomT:     "+",
+		LeftT:       "+",
+		RightT:      "+",
+		Cross:       "+",
+	}
+
+	// Single draws a border using light box-drawing characters.
+	Single = Set{
+		Horizontal:  "─",
+		Vertical:    "│",
+		TopLeft:     "┌",
+		TopRight:    "┐",
+		BottomLeft:  "└",
+		BottomRight: "┘",
+		TopT:        "┬",
+		BottomT:     "┴",
+		LeftT:       "├",
+		RightT:      "┤",
+		Cross:       "┼",
+	}
+
+	// Double draws a border using double box-drawing characters.
+	Double = Set{
+		Horizontal:  "═",
+		Vertical:    "║",
+		TopLeft:     "╔",
+		TopRight:    "╗",
+		BottomLeft:  "╚",
+		BottomRight: "╝",
+		TopT:        "╦",
+		BottomT:     "╩",
+		LeftT:       "╠",
+		RightT:      "╣",
+		Cross:       "╬",
+	}
+
+	// Rounded draws a border like [Single], but with rounded corners.
+	Rounded = Set{
+		Horizontal:  "─",
+		Vertical:    "│",
+		TopLeft:     "╭",
+		TopRight:    "╮",
+		BottomLeft:  "╰",
+		BottomRight: "╯",
+		TopT:        "┬",
+		BottomT:     "┴",
+		LeftT:       "├",
+		RightT:      "┤",
+		Cross:       "┼",
+	}
+
+	// Heavy draws a border using heavy box-drawing characters.
+	Heavy = Set{
+		Horizontal:  "━",
+		Vertical:    "┃",
+		TopLeft:     "┏",
+		TopRight:    "┓",
+		BottomLeft:  "┗",
+		BottomRight: "┛",
+		TopT:        "┳",
+		BottomT:     "┻",
+		LeftT:       "┣",
+		RightT:      "┫",
+		Cross:       "╋",
+	}
+)
+
+// IsZero returns whether s draws no border.
+func (s Set) IsZero() bool {
+	return s == Set{}
+}
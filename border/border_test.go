@@ -0,0 +1,60 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package border_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/border"
+)
+
+func TestSets(t *testing.T) {
+	require.True(t, border.None.IsZero())
+
+	sets := map[string]border.Set{
+		"ascii":   border.ASCII,
+		"single":  border.Single,
+		"double":  border.Double,
+		"rounded": border.Rounded,
+		"heavy":   border.Heavy,
+	}
+
+	for name, set := range sets {
+		require.False(t, set.IsZero(), name)
+		for _, str := range []string{
+			set.Horizontal,
+			set.Vertical,
+			set.TopLeft,
+			set.TopRight,
+			set.BottomLeft,
+			set.BottomRight,
+			set.TopT,
+			set.BottomT,
+			set.LeftT,
+			set.RightT,
+			set.Cross,
+		} {
+			require.Equal(t, 1, color.VisibleWidth(str), "%s: %q", name, str)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package box draws borders around styled content.
+package box
+
+import (
+	"io"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+// A Box draws a border, with an optional title, around content. Content may
+// span multiple lines and contain escape sequences; widths are measured in
+// visible cells.
+type Box struct {
+	opts Options
+}
+
+// New creates a new [Box] with the given options.
+func New(opts ...Option) Box {
+	return Box{
+		opts: DefaultOptions().With(opts...),
+	}
+}
+
+// Render is a convenience function that calls New(opts...).Render(content).
+func Render(content string, opts ...Option) string {
+	return New(opts...).Render(content)
+}
+
+// Fprint writes content, drawn inside b, to w.
+func (b Box) Fprint(w io.Writer, content string) (int, error) {
+	return io.WriteString(w, b.Render(content))
+}
+
+// Render returns content drawn inside b. The result ends with a newline.
+func (b Box) Render(content string) string {
+	var (
+		opts   = b.opts
+		lines  = color.SplitLines(content)
+		inner  = b.innerWidth(lines)
+		span   = inner + opts.Padding.Left + opts.Padding.Right
+		margin = strings.Repeat(" ", opts.Margin.Left)
+		buf    strings.Builder
+	)
+
+	if opts.Width > 0 {
+		lines = wrap(lines, inner)
+	}
+
+	writeLine := func(str string) {
+		buf.WriteString(margin)
+		buf.WriteString(str)
+		buf.WriteString(strings.Repeat(" ", opts.Margin.Right))
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString(strings.Repeat("\n", opts.Margin.Top))
+	if top, ok := b.top(span); ok {
+		writeLine(top)
+	}
+
+	var (
+		left, right = b.sides()
+		padLeft     = strings.Repeat(" ", opts.Padding.Left)
+		padRight    = strings.Repeat(" ", opts.Padding.Right)
+		blank       = left + strings.Repeat(" ", span) + right
+	)
+
+	for i := 0; i < opts.Padding.Top; i++ {
+		writeLine(blank)
+	}
+	for _, line := range lines {
+		gap := strings.Repeat(" ", max(inner-color.VisibleWidth(line), 0))
+		writeLine(left + padLeft + line + gap + padRight + right)
+	}
+	for i := 0; i < opts.Padding.Bottom; i++ {
+		writeLine(blank)
+	}
+
+	if bottom, ok := b.bottom(span); ok {
+		writeLine(bottom)
+	}
+	buf.WriteString(strings.Repeat("\n", opts.Margin.Bottom))
+
+	return buf.String()
+}
+
+// innerWidth returns the width available to content.
+func (b Box) innerWidth(lines []string) int {
+	var (
+		opts    = b.opts
+		padding = opts.Padding.Left + opts.Padding.Right
+	)
+
+	if opts.Width > 0 {
+		return max(opts.Width-padding-b.borderWidth(), 1)
+	}
+
+	var width int
+	for _, line := range lines {
+		width = max(width, color.VisibleWidth(line))
+	}
+
+	if len(opts.Title) > 0 {
+		width = max(width, color.VisibleWidth(opts.Title)+b.titleOverhead()-padding)
+	}
+
+	return width
+}
+
+func (b Box) borderWidth() int {
+	if b.opts.Border.IsZero() {
+		return 0
+	}
+	return 2
+}
+
+// titleOverhead returns the number of cells surrounding the title in the top
+// border, excluding corners.
+func (b Box) titleOverhead() int {
+	if b.opts.Border.IsZero() {
+		return 0
+	}
+	return 4
+}
+
+func (b Box) sides() (string, string) {
+	set := b.opts.Border
+	if set.IsZero() {
+		return "", ""
+	}
+	return b.opts.BorderStyle.Wrap(set.Vertical), b.opts.BorderStyle.Wrap(set.Vertical)
+}
+
+func (b Box) top(span int) (string, bool) {
+	var (
+		set   = b.opts.Border
+		style = b.opts.BorderStyle
+	)
+
+	if set.IsZero() {
+		if len(b.opts.Title) == 0 {
+			return "", false
+		}
+		return b.title(span), true
+	}
+
+	// Omit the title if there is no room for any of it.
+	avail := span - b.titleOverhead()
+	if len(b.opts.Title) == 0 || avail < 1 {
+		return style.Wrap(set.TopLeft + strings.Repeat(set.Horizontal, span) + set.TopRight), true
+	}
+
+	title := b.title(avail)
+	rest := max(avail-color.VisibleWidth(title), 0)
+
+	return style.Wrap(set.TopLeft+set.Horizontal+" ") +
+		title +
+		style.Wrap(" "+strings.Repeat(set.Horizontal, rest+1)+set.TopRight), true
+}
+
+func (b Box) title(width int) string {
+	return b.opts.TitleStyle.Wrap(color.Truncate(b.opts.Title, max(width, 0), "…"))
+}
+
+func (b Box) bottom(span int) (string, bool) {
+	set := b.opts.Border
+	if set.IsZero() {
+		return "", false
+	}
+
+	return b.opts.BorderStyle.Wrap(
+		set.BottomLeft + strings.Repeat(set.Horizontal, span) + set.BottomRight,
+	), true
+}
+
+// wrap word-wraps any of lines that are wider than width.
+func wrap(lines []string, width int) []string {
+	dst := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if color.VisibleWidth(line) <= width {
+			dst = append(dst, line)
+			continue
+		}
+		dst = append(dst, color.SplitLines(color.WordWrap(line, width))...)
+	}
+	return dst
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package box_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/border"
+	"go.mway.dev/color/box"
+	"go.mway.dev/color/colortest"
+)
+
+func lines(strs ...string) string {
+	return strings.Join(strs, "\n") + "\n"
+}
+
+func TestRender(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	require.Equal(t, lines(
+		"┌─────┐",
+		"│ foo │",
+		"│ ba  │",
+		"└─────┘",
+	), box.Render("foo\nba"))
+
+	require.Equal(t, lines(
+		"╭─ Title ─╮",
+		"│ x       │",
+		"╰─────────╯",
+	), box.Render("x", box.WithBorder(border.Rounded), box.WithTitle("Title", color.Bold)))
+
+	require.Equal(t, lines(
+		"┌─ L… ─┐",
+		"│ x    │",
+		"└──────┘",
+	), box.Render("x", box.WithWidth(8), box.WithTitle("LongTitle", color.Nop)))
+
+	require.Equal(t, lines(
+		"+---------+",
+		"|  one    |",
+		"|  two    |",
+		"|  three  |",
+		"+---------+",
+	), box.Render(
+		"one two three",
+		box.WithBorder(border.ASCII),
+		box.WithWidth(11),
+		box.WithPadding(box.Symmetric(0, 2)),
+	))
+}
+
+func TestRender_Spacing(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	require.Equal(t, "\n"+lines(
+		"  ╔═══╗ ",
+		"  ║   ║ ",
+		"  ║ x ║ ",
+		"  ║   ║ ",
+		"  ╚═══╝ ",
+	)+"\n\n", box.Render(
+		"x",
+		box.WithBorder(border.Double),
+		box.WithPadding(box.Uniform(1)),
+		box.WithMargin(box.Spacing{Top: 1, Right: 1, Bottom: 2, Left: 2}),
+	))
+
+	require.Equal(t, lines(
+		"Title",
+		" ab  ",
+	), box.Render("ab", box.WithBorder(border.None), box.WithTitle("Title", color.Nop)))
+}
+
+func TestRender_Styled(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		bdr     = color.FgBlue
+		content = color.FgRed.Sprint("red") + "\n" + color.Bold.Sprint("x")
+		got     = box.Render(
+			content,
+			box.WithBorder(border.Heavy),
+			box.WithBorderStyle(bdr),
+			box.WithTitle("T", color.FgGreen),
+		)
+	)
+
+	require.Equal(t, lines(
+		bdr.Wrap("┏━ ")+color.FgGreen.Wrap("T")+bdr.Wrap(" ━┓"),
+		bdr.Wrap("┃")+" "+color.FgRed.Wrap("red")+" "+bdr.Wrap("┃"),
+		bdr.Wrap("┃")+" "+color.Bold.Wrap("x")+"   "+bdr.Wrap("┃"),
+		bdr.Wrap("┗━━━━━┛"),
+	), got)
+
+	wrapped := box.Render(color.FgRed.Sprint("aa bb"), box.WithWidth(6))
+	require.Equal(t, lines(
+		"┌────┐",
+		"│ "+color.FgRed.Wrap("aa")+" │",
+		"│ "+color.FgRed.Wrap("bb")+" │",
+		"└────┘",
+	), wrapped)
+
+	var buf bytes.Buffer
+	n, err := box.New().Fprint(&buf, "x")
+	require.NoError(t, err)
+	require.Equal(t, buf.Len(), n)
+	require.Equal(t, box.Render("x"), buf.String())
+}
+
+func TestOptions(t *testing.T) {
+	opts := box.DefaultOptions().With(box.Options{
+		Border:      border.Heavy,
+		BorderStyle: color.FgRed,
+		Title:       "title",
+		TitleStyle:  color.Bold,
+		Padding:     box.Uniform(2),
+		Margin:      box.Uniform(1),
+		Width:       40,
+	})
+
+	require.Equal(t, border.Heavy, opts.Border)
+	require.Equal(t, color.FgRed, opts.BorderStyle)
+	require.Equal(t, "title", opts.Title)
+	require.Equal(t, color.Bold, opts.TitleStyle)
+	require.Equal(t, box.Uniform(2), opts.Padding)
+	require.Equal(t, box.Uniform(1), opts.Margin)
+	require.Equal(t, 40, opts.Width)
+}
+
+func TestRender_Narrow(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	require.Equal(t, lines(
+		"┌─┐",
+		"│x│",
+		"└─┘",
+	), box.Render(
+		"x",
+		box.WithWidth(3),
+		box.WithPadding(box.Spacing{}),
+		box.WithTitle("hello", color.Bold),
+	))
+
+	require.Equal(t, lines(
+		"┌─ … ─┐",
+		"│x    │",
+		"└─────┘",
+	), box.Render(
+		"x",
+		box.WithWidth(7),
+		box.WithPadding(box.Spacing{}),
+		box.WithTitle("hello", color.Bold),
+	))
+}
+
+func TestRender_NilStyles(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	require.Equal(t, lines(
+		"┌─ t ─┐",
+		"│ x   │",
+		"└─────┘",
+	), box.Render("x", box.WithTitle("t", nil), box.WithBorderStyle(nil)))
+}
+
+func TestRender_NegativeSpacing(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	want := lines(
+		"┌─┐",
+		"│x│",
+		"└─┘",
+	)
+	require.NotPanics(t, func() {
+		require.Equal(t, want, box.Render(
+			"x",
+			box.WithPadding(box.Uniform(-1)),
+			box.WithMargin(box.Symmetric(-2, -3)),
+		))
+	})
+	require.Equal(t, want, box.Render("x", box.Options{
+		Padding: box.Spacing{Top: -1, Right: -1},
+		Margin:  box.Uniform(-1),
+	}))
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package box
+
+import (
+	"go.mway.dev/color"
+	"go.mway.dev/color/border"
+)
+
+// Spacing is an amount of space on each side of a box, in cells (or lines,
+// for Top and Bottom).
+type Spacing struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// Uniform returns a [Spacing] of n on every side.
+func Uniform(n int) Spacing {
+	return Spacing{Top: n, Right: n, Bottom: n, Left: n}
+}
+
+// Symmetric returns a [Spacing] of vertical above and below, and horizontal
+// to the left and right.
+func Symmetric(vertical int, horizontal int) Spacing {
+	return Spacing{Top: vertical, Right: horizontal, Bottom: vertical, Left: horizontal}
+}
+
+// IsZero returns whether s adds no space.
+func (s Spacing) IsZero() bool {
+	return s == Spacing{}
+}
+
+// clamp returns s with negative sides replaced by zero.
+func (s Spacing) clamp() Spacing {
+	return Spacing{
+		Top:    max(s.Top, 0),
+		Right:  max(s.Right, 0),
+		Bottom: max(s.Bottom, 0),
+		Left:   max(s.Left, 0),
+	}
+}
+
+// Options configure a [Box].
+type Options struct {
+	// Border is the set of characters used to draw the box.
+	Border border.Set
+	// BorderStyle is the style used to draw the border.
+	BorderStyle color.Style
+	// Title, if not empty, is drawn in the top border.
+	Title string
+	// TitleStyle is the style used to draw the title.
+	TitleStyle color.Style
+	// Padding is the space between the border and the content.
+	Padding Spacing
+	// Margin is the space outside of the border.
+	Margin Spacing
+	// Width, if positive, is the fixed visible width of the box including
+	// its border and padding but excluding its margin. Content is wrapped to
+	// fit. Otherwise, the box is sized to fit its content.
+	Width int
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Border:      border.Single,
+		BorderStyle: color.Nop,
+		TitleStyle:  color.Nop,
+		Padding:     Symmetric(0, 1),
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	if !o.Border.IsZero() {
+		dst.Border = o.Border
+	}
+	if o.BorderStyle != nil {
+		dst.BorderStyle = o.BorderStyle
+	}
+	if len(o.Title) > 0 {
+		dst.Title = o.Title
+	}
+	if o.TitleStyle != nil {
+		dst.TitleStyle = o.TitleStyle
+	}
+	if !o.Padding.IsZero() {
+		dst.Padding = o.Padding.clamp()
+	}
+	if !o.Margin.IsZero() {
+		dst.Margin = o.Margin.clamp()
+	}
+	if o.Width > 0 {
+		dst.Width = o.Width
+	}
+}
+
+// An Option configures a [Box].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithBorder returns an [Option] that sets the box's border characters.
+func WithBorder(set border.Set) Option {
+	return optionFunc(func(o *Options) {
+		o.Border = set
+	})
+}
+
+// WithBorderStyle returns an [Option] that sets the box's border style. A nil
+// style is treated as [color.Nop].
+func WithBorderStyle(style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.BorderStyle = orNop(style)
+	})
+}
+
+// WithTitle returns an [Option] that sets the box's title and its style. A
+// nil style is treated as [color.Nop].
+func WithTitle(title string, style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.Title = title
+		o.TitleStyle = orNop(style)
+	})
+}
+
+// WithPadding returns an [Option] that sets the space between the box's
+// border and its content. Negative sides are treated as zero.
+func WithPadding(padding Spacing) Option {
+	return optionFunc(func(o *Options) {
+		o.Padding = padding.clamp()
+	})
+}
+
+// WithMargin returns an [Option] that sets the space outside of the box's
+// border. Negative sides are treated as zero.
+func WithMargin(margin Spacing) Option {
+	return optionFunc(func(o *Options) {
+		o.Margin = margin.clamp()
+	})
+}
+
+// WithWidth returns an [Option] that fixes the box's visible width. A width
+// <= 0 sizes the box to fit its content.
+func WithWidth(width int) Option {
+	return optionFunc(func(o *Options) {
+		o.Width = width
+	})
+}
+
+// orNop returns style, or [color.Nop] if style is nil.
+func orNop(style color.Style) color.Style {
+	if style == nil {
+		return color.Nop
+	}
+	return style
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+
+	"go.mway.dev/errors"
+)
+
+// generate returns the source of a Go file in package pkg that declares a
+// color.SGR constant for each of t's styles. source is the name of the
+// theme file, which is mentioned in the generated file's header.
+func generate(t theme, pkg string, source string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, errors.Wrap(ErrInvalidTheme, fmt.Sprintf("bad package name %q", pkg))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by colorgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"go.mway.dev/color\"\n\n")
+	fmt.Fprintf(&buf, "// Styles generated from %s.\n", source)
+	buf.WriteString("const (\n")
+
+	for _, name := range t.names() {
+		// A style named "color" would shadow the import, and "init" cannot
+		// be declared as a constant.
+		if !token.IsIdentifier(name) || name == "color" || name == "init" {
+			return nil, errors.Wrap(ErrInvalidTheme, fmt.Sprintf("bad style name %q", name))
+		}
+
+		spec := t.Styles[name]
+		style, err := spec.Style()
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+
+		if len(spec) == 0 {
+			fmt.Fprintf(&buf, "\t// %s is unstyled.\n", name)
+		} else {
+			fmt.Fprintf(&buf, "\t// %s is %s.\n", name, spec)
+		}
+		fmt.Fprintf(&buf, "\t%s color.SGR = %s\n", name, strconv.Quote(style.String()))
+	}
+	buf.WriteString(")\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "format generated source")
+	}
+	return src, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "theme.yaml"))
+	require.NoError(t, err)
+
+	th, err := parseTheme(data)
+	require.NoError(t, err)
+
+	got, err := generate(th, th.Package, "theme.yaml")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(filepath.Join("testdata", "theme_gen.golden"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestGenerate_Error(t *testing.T) {
+	valid := theme{Styles: map[string]spec{"A": {"bold"}}}
+
+	_, err := generate(valid, "not a package", "x.yaml")
+	require.ErrorIs(t, err, ErrInvalidTheme)
+
+	_, err = generate(theme{Styles: map[string]spec{"1A": {"bold"}}}, "x", "x.yaml")
+	require.ErrorIs(t, err, ErrInvalidTheme)
+
+	_, err = generate(theme{Styles: map[string]spec{"A": {"fg:nope"}}}, "x", "x.yaml")
+	require.ErrorIs(t, err, ErrInvalidTheme)
+
+	for _, name := range []string{"color", "init"} {
+		_, err = generate(theme{Styles: map[string]spec{name: {"bold"}}}, "x", "x.yaml")
+		require.ErrorIs(t, err, ErrInvalidTheme, name)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var (
+		dir  = t.TempDir()
+		in   = filepath.Join(dir, "colors.json")
+		out  = filepath.Join(dir, "colors_gen.go")
+		want = "// Code generated by colorgen from colors.json. DO NOT EDIT.\n\n" +
+			"package ui\n\n" +
+			"import \"go.mway.dev/color\"\n\n" +
+			"// Styles generated from colors.json.\n" +
+			"const (\n" +
+			"\t// Title is bold fg:cyan.\n" +
+			"\tTitle color.SGR = \"\\x1b[1;36m\"\n" +
+			")\n"
+	)
+
+	require.NoError(t, os.WriteFile(in, []byte(`{"styles": {"Title": "bold fg:cyan"}}`), 0o600))
+
+	t.Setenv("GOPACKAGE", "ui")
+	require.NoError(t, run([]string{in}, &bytes.Buffer{}))
+
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, want, string(got))
+
+	// Flags take precedence over the environment.
+	other := filepath.Join(dir, "other.go")
+	require.NoError(t, run([]string{"-in", in, "-out", other, "-package", "styles"}, &bytes.Buffer{}))
+
+	got, err = os.ReadFile(other)
+	require.NoError(t, err)
+	require.Contains(t, string(got), "package styles\n")
+}
+
+func TestRun_Error(t *testing.T) {
+	var stderr bytes.Buffer
+	require.Error(t, run(nil, &stderr))
+	require.Contains(t, stderr.String(), "-in")
+
+	require.Error(t, run([]string{"-bogus"}, &stderr))
+	require.Error(t, run([]string{filepath.Join(t.TempDir(), "missing.yaml")}, &stderr))
+
+	bad := filepath.Join(t.TempDir(), "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("styles: {A: bolder}"), 0o600))
+	require.ErrorIs(t, run([]string{bad}, &stderr), ErrInvalidTheme)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Command colorgen generates Go constants for the styles defined in a YAML or
+// JSON theme file, so that themes can be maintained outside of Go code. Each
+// style is declared as a color.SGR constant whose escape sequence is
+// computed ahead of time.
+//
+// A theme file maps style names to lists of attributes and colors:
+//
+//	package: theme
+//	styles:
+//	  Error: bold fg:red
+//	  Warning: [fg:yellow]
+//	  Accent: fg:208 bg:#1e1e2e
+//
+// Attributes are bold, faint, italic, underline, blink-slow, blink-rapid,
+// reverse, concealed, and crossed-out. Colors are "fg:" or "bg:" followed by
+// a color name (e.g. "red" or "hi-red"), a 256-color palette index, or a
+// "#rrggbb" hex color.
+//
+// Usage:
+//
+//	//go:generate go run go.mway.dev/color/cmd/colorgen -in theme.yaml
+//
+// By default, the output file is named after the input file with a "_gen.go"
+// suffix, and its package is the theme's package, $GOPACKAGE (as set by go
+// generate), or "theme", in that order.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.mway.dev/errors"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "colorgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stderr io.Writer) error {
+	var (
+		flags = flag.NewFlagSet("colorgen", flag.ContinueOnError)
+		in    = flags.String("in", "", "theme file (YAML or JSON)")
+		out   = flags.String("out", "", "output file (default: input file with a _gen.go suffix)")
+		pkg   = flags.String("package", "", "package name (default: theme package or $GOPACKAGE)")
+	)
+	flags.SetOutput(stderr)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(*in) == 0 {
+		*in = flags.Arg(0)
+	}
+	if len(*in) == 0 {
+		flags.Usage()
+		return errors.New("no theme file given")
+	}
+	if len(*out) == 0 {
+		*out = strings.TrimSuffix(*in, filepath.Ext(*in)) + "_gen.go"
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+
+	t, err := parseTheme(data)
+	if err != nil {
+		return errors.Wrap(err, *in)
+	}
+
+	name := *pkg
+	for _, candidate := range []string{t.Package, os.Getenv("GOPACKAGE"), "theme"} {
+		if len(name) == 0 {
+			name = candidate
+		}
+	}
+
+	src, err := generate(t, name, filepath.Base(*in))
+	if err != nil {
+		return errors.Wrap(err, *in)
+	}
+
+	return os.WriteFile(*out, src, 0o644) //nolint:gosec
+}
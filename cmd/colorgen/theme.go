@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mway.dev/color"
+	"go.mway.dev/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTheme indicates that a theme file could not be parsed.
+var ErrInvalidTheme = errors.New("invalid theme")
+
+// _attrs maps attribute names to their [color.Color]s.
+var _attrs = map[string]color.Color{
+	"bold":        color.Bold,
+	"faint":       color.Faint,
+	"italic":      color.Italic,
+	"underline":   color.Underline,
+	"blink-slow":  color.BlinkSlow,
+	"blink-rapid": color.BlinkRapid,
+	"reverse":     color.ReverseVideo,
+	"concealed":   color.Concealed,
+	"crossed-out": color.CrossedOut,
+}
+
+// A theme is a set of named styles, as read from a theme file.
+type theme struct {
+	// Package is the name of the generated file's package.
+	Package string `yaml:"package"`
+	// Styles are the theme's styles, by name.
+	Styles map[string]spec `yaml:"styles"`
+}
+
+// A spec is a style specification: a list of attribute names, such as
+// "bold", and colors, such as "fg:red", "fg:208", or "bg:#1e1e2e". In a
+// theme file, it is either a list or a string of space- or comma-separated
+// tokens.
+type spec []string
+
+// UnmarshalYAML implements [yaml.Unmarshaler].
+func (s *spec) UnmarshalYAML(node *yaml.Node) error {
+	var tokens []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		tokens = []string{node.Value}
+	case yaml.SequenceNode:
+		if err := node.Decode(&tokens); err != nil {
+			return err
+		}
+	default:
+		return errors.Wrap(
+			ErrInvalidTheme,
+			fmt.Sprintf("line %d: style must be a string or list", node.Line),
+		)
+	}
+
+	*s = (*s)[:0]
+	for _, token := range tokens {
+		*s = append(*s, strings.FieldsFunc(token, func(r rune) bool {
+			return r == ' ' || r == ','
+		})...)
+	}
+	return nil
+}
+
+// String returns s as a space-separated list of tokens.
+func (s spec) String() string {
+	return strings.Join(s, " ")
+}
+
+// Style returns the style that s specifies.
+func (s spec) Style() (color.Style, error) {
+	styles := make([]color.Style, 0, len(s))
+	for _, token := range s {
+		style, err := parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		styles = append(styles, style)
+	}
+	return color.Combine(styles...), nil
+}
+
+// parseTheme parses a YAML or JSON theme file.
+func parseTheme(data []byte) (theme, error) {
+	var t theme
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return theme{}, errors.Wrap(ErrInvalidTheme, err.Error())
+	}
+	if len(t.Styles) == 0 {
+		return theme{}, errors.Wrap(ErrInvalidTheme, "no styles")
+	}
+	return t, nil
+}
+
+// names returns the names of t's styles in sorted order.
+func (t theme) names() []string {
+	names := make([]string, 0, len(t.Styles))
+	for name := range t.Styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseToken parses a single attribute or color token.
+func parseToken(token string) (color.Style, error) {
+	if attr, ok := _attrs[token]; ok {
+		return attr, nil
+	}
+
+	kind, value, ok := strings.Cut(token, ":")
+	if !ok || (kind != "fg" && kind != "bg") {
+		return nil, errors.Wrap(ErrInvalidTheme, fmt.Sprintf("unknown token %q", token))
+	}
+	fg := kind == "fg"
+
+	if strings.HasPrefix(value, "#") {
+		rgb, err := parseHex(value)
+		if err != nil {
+			return nil, err
+		}
+		if fg {
+			return rgb.Fg(), nil
+		}
+		return rgb.Bg(), nil
+	}
+
+	if n, err := strconv.ParseUint(value, 10, 8); err == nil {
+		if fg {
+			return color.Fg256(uint8(n)), nil
+		}
+		return color.Bg256(uint8(n)), nil
+	}
+
+	parse := color.ParseBgColor
+	if fg {
+		parse = color.ParseFgColor
+	}
+	c, err := parse(value)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidTheme, err.Error())
+	}
+	return c, nil
+}
+
+// parseHex parses an "#rrggbb" color.
+func parseHex(value string) (color.RGB, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(value, "#"), 16, 32)
+	if err != nil || len(value) != 7 {
+		return color.RGB{}, errors.Wrap(ErrInvalidTheme, fmt.Sprintf("bad hex color %q", value))
+	}
+	return color.RGB{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, nil
+}
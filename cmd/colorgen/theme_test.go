@@ -0,0 +1,117 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+)
+
+func TestParseTheme(t *testing.T) {
+	cases := map[string]struct {
+		give string
+		want theme
+	}{
+		"yaml": {
+			give: "package: x\nstyles:\n  A: bold fg:red\n  B: [fg:208, 'bg:#102030']\n",
+			want: theme{
+				Package: "x",
+				Styles: map[string]spec{
+					"A": {"bold", "fg:red"},
+					"B": {"fg:208", "bg:#102030"},
+				},
+			},
+		},
+		"json": {
+			give: `{"styles": {"A": "italic,bg:hi-blue", "B": ["faint"]}}`,
+			want: theme{
+				Styles: map[string]spec{
+					"A": {"italic", "bg:hi-blue"},
+					"B": {"faint"},
+				},
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseTheme([]byte(tt.give))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTheme_Error(t *testing.T) {
+	for _, give := range []string{
+		"styles: [",
+		"package: x",
+		"styles:\n  A: {fg: red}",
+	} {
+		_, err := parseTheme([]byte(give))
+		require.ErrorIs(t, err, ErrInvalidTheme, give)
+	}
+}
+
+func TestSpec_Style(t *testing.T) {
+	cases := map[string]struct {
+		give spec
+		want color.Style
+	}{
+		"empty":   {give: spec{}, want: color.Nop},
+		"attr":    {give: spec{"crossed-out"}, want: color.CrossedOut},
+		"fg name": {give: spec{"fg:hi-magenta"}, want: color.FgHiMagenta},
+		"bg name": {give: spec{"bg:green"}, want: color.BgGreen},
+		"fg 256":  {give: spec{"fg:0"}, want: color.Fg256(0)},
+		"bg 256":  {give: spec{"bg:255"}, want: color.Bg256(255)},
+		"fg rgb":  {give: spec{"fg:#ff8000"}, want: color.RGB{R: 255, G: 128}.Fg()},
+		"bg rgb":  {give: spec{"bg:#000001"}, want: color.RGB{B: 1}.Bg()},
+		"combined": {
+			give: spec{"bold", "fg:red", "bg:17"},
+			want: color.Bold.With(color.FgRed, color.Bg256(17)),
+		},
+		"attributes": {give: spec{"faint", "reverse"}, want: color.Faint.With(color.ReverseVideo)},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.give.Style()
+			require.NoError(t, err)
+			require.Equal(t, tt.want.String(), got.String())
+		})
+	}
+}
+
+func TestSpec_StyleError(t *testing.T) {
+	for _, token := range []string{
+		"bolder",
+		"ul:red",
+		"fg:purple",
+		"bg:256",
+		"fg:#12345",
+		"fg:#gggggg",
+	} {
+		_, err := spec{token}.Style()
+		require.ErrorIs(t, err, ErrInvalidTheme, token)
+	}
+}
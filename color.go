@@ -103,8 +103,9 @@ var (
 
 	_fd       = os.Stderr.Fd()
 	_stdout   = bufio.NewWriter(os.Stdout)
-	_hasColor = !isset("NO_COLOR") && os.Getenv("TERM") != "dumb" &&
-		(isatty.IsTerminal(_fd) || isatty.IsCygwinTerminal(_fd))
+	_colorEnv = !isset("NO_COLOR") && os.Getenv("TERM") != "dumb"
+	_hasColor = _colorEnv && isTerminal(_fd)
+	_forced   bool
 	_builders = pool.NewWithReleaser(
 		func() *bytes.Buffer { return bytes.NewBuffer(make([]byte, 0, 256)) },
 		func(x *bytes.Buffer) { x.Reset() },
@@ -686,6 +687,45 @@ func Enabled() bool {
 	return _hasColor
 }
 
+// OverrideEnabled overrides whether color is enabled, including for
+// [EnabledFor], and returns a function that restores the previous state,
+// including whether color was overridden at all. It is intended for tests,
+// e.g. via t.Cleanup(color.OverrideEnabled(true)), and is not safe to call
+// concurrently with other functions in this package.
+func OverrideEnabled(enabled bool) (restore func()) {
+	prevEnabled, prevForced := _hasColor, _forced
+	_hasColor, _forced = enabled, true
+	return func() {
+		_hasColor, _forced = prevEnabled, prevForced
+	}
+}
+
+// EnabledFor returns whether color is enabled for output written to w. Unless
+// overridden by [OverrideEnabled], this requires that w is a terminal.
+func EnabledFor(w io.Writer) bool {
+	if _forced {
+		return _hasColor
+	}
+	return _colorEnv && IsTerminal(w)
+}
+
+// IsTerminal returns whether w is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Fd() uintptr })
+	return ok && isTerminal(f.Fd())
+}
+
+// TerminalSize returns the width of the terminal w in cells and its height
+// in lines, or zeros if w is not a terminal or its size cannot be determined.
+func TerminalSize(w io.Writer) (width int, height int) {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok || !isTerminal(f.Fd()) {
+		return 0, 0
+	}
+	width, height = terminalSize(f.Fd())
+	return max(width, 0), max(height, 0)
+}
+
 // Copy is a convenience function that calls s.Copy(dst, src).
 func Copy(s Style, dst io.Writer, src io.Reader) (int64, error) {
 	return s.Copy(dst, src)
@@ -746,6 +786,10 @@ func Fprintln(dst io.Writer, s Style, args ...any) (int, error) {
 	return s.Fprintln(dst, args...)
 }
 
+func isTerminal(fd uintptr) bool {
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
 func isset(key string) bool {
 	_, ok := os.LookupEnv(key)
 	return ok
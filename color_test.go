@@ -23,6 +23,7 @@ package color
 import (
 	"bufio"
 	"bytes"
+	"os"
 	"strconv"
 	"testing"
 
@@ -494,3 +495,43 @@ func TestParseBgColor(t *testing.T) {
 	require.ErrorIs(t, err, ErrInvalidColorName)
 	require.ErrorContains(t, err, "unknown-color-name")
 }
+
+func TestTerminalSize(t *testing.T) {
+	var buf bytes.Buffer
+	width, height := TerminalSize(&buf)
+	require.Zero(t, width)
+	require.Zero(t, height)
+
+	f, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+	width, height = TerminalSize(f)
+	require.Zero(t, width)
+	require.Zero(t, height)
+}
+
+func TestOverrideEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	require.False(t, EnabledFor(&buf))
+	require.False(t, IsTerminal(&buf))
+
+	restore := OverrideEnabled(true)
+	t.Cleanup(restore)
+	require.True(t, Enabled())
+	require.True(t, EnabledFor(&buf))
+	require.Equal(t, FgRed.String(), FgRed.Escape())
+
+	restoreInner := OverrideEnabled(false)
+	require.False(t, Enabled())
+	require.False(t, EnabledFor(&buf))
+	require.Equal(t, "", FgRed.Escape())
+
+	restoreInner()
+	require.True(t, Enabled())
+	require.True(t, EnabledFor(&buf))
+
+	restore()
+	require.True(t, Enabled())
+	require.False(t, _forced)
+	require.False(t, EnabledFor(&buf))
+}
@@ -0,0 +1,353 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package colorjson pretty-prints JSON with [color.Style]s applied to its
+// keys, values, and punctuation.
+package colorjson
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	"go.mway.dev/color"
+	"go.mway.dev/errors"
+)
+
+// ErrSyntax is returned when input is not valid JSON.
+var ErrSyntax = errors.New("invalid JSON")
+
+// Marshal returns v encoded as indented, styled JSON. When color is disabled,
+// the result is identical to that of [encoding/json.MarshalIndent] with the
+// same prefix and indent.
+func Marshal(v any, opts ...Option) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, opts...).Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// An Encoder writes indented, styled JSON to a writer.
+type Encoder struct {
+	w    io.Writer
+	opts Options
+}
+
+// NewEncoder creates a new [Encoder] that writes to w.
+func NewEncoder(w io.Writer, opts ...Option) *Encoder {
+	return &Encoder{
+		w:    w,
+		opts: DefaultOptions().With(opts...),
+	}
+}
+
+// Encode writes the JSON encoding of v, followed by a newline. When color is
+// disabled, the output is identical to that of an [encoding/json.Encoder]
+// configured with the same prefix and indent. Maps, slices, and arrays in v
+// are written element by element as they are encoded, rather than after the
+// entire document has been encoded.
+func (e *Encoder) Encode(v any) error {
+	var (
+		pr, pw = io.Pipe()
+		done   = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		w := bufio.NewWriter(pw)
+		err := walk(w, reflect.ValueOf(v), 0)
+		if err == nil {
+			err = w.Flush()
+		}
+		pw.CloseWithError(err) //nolint:errcheck
+	}()
+
+	err := e.Stream(pr)
+	// Unblock the encoder if printing stopped early, and wait for it so that
+	// v is not read after Encode returns.
+	pr.Close() //nolint:errcheck
+	<-done
+	return err
+}
+
+// Stream reads JSON values from r and writes each of them, followed by a
+// newline. Values are written as they are read; neither the input nor the
+// output is held in memory in its entirety.
+func (e *Encoder) Stream(r io.Reader) error {
+	p := printer{
+		r:    bufio.NewReader(r),
+		w:    bufio.NewWriter(e.w),
+		opts: e.opts,
+	}
+
+	err := p.stream()
+	if ferr := p.w.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+type printer struct {
+	r      *bufio.Reader
+	w      *bufio.Writer
+	opts   Options
+	offset int
+}
+
+func (p *printer) stream() error {
+	for {
+		c, err := p.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := p.value(c, 0); err != nil {
+			return err
+		}
+		p.w.WriteByte('\n') //nolint:errcheck
+	}
+}
+
+// next returns the next byte that is not whitespace.
+func (p *printer) next() (byte, error) {
+	for {
+		c, err := p.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		p.offset++
+
+		switch c {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return c, nil
+		}
+	}
+}
+
+// mustNext is like next, but treats EOF as a syntax error.
+func (p *printer) mustNext() (byte, error) {
+	c, err := p.next()
+	if errors.Is(err, io.EOF) {
+		return 0, p.syntaxError("unexpected end of input")
+	}
+	return c, err
+}
+
+func (p *printer) value(c byte, depth int) error {
+	styles := p.opts.Styles
+
+	switch {
+	case c == '{':
+		return p.container('}', depth, true)
+	case c == '[':
+		return p.container(']', depth, false)
+	case c == '"':
+		return p.string(styles.String)
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number(c)
+	case c == 't' || c == 'f':
+		return p.literal(c, styles.Bool, "true", "false")
+	case c == 'n':
+		return p.literal(c, styles.Null, "null")
+	default:
+		return p.syntaxError(fmt.Sprintf("unexpected %q", c))
+	}
+}
+
+func (p *printer) container(closer byte, depth int, object bool) error {
+	opener := '['
+	if object {
+		opener = '{'
+	}
+
+	c, err := p.mustNext()
+	if err != nil {
+		return err
+	}
+
+	if c == closer {
+		p.punct(string(opener) + string(closer))
+		return nil
+	}
+	p.punct(string(opener))
+
+	for {
+		p.newline(depth + 1)
+
+		if object {
+			if c, err = p.key(c); err != nil {
+				return err
+			}
+		}
+
+		if err = p.value(c, depth+1); err != nil {
+			return err
+		}
+
+		if c, err = p.mustNext(); err != nil {
+			return err
+		}
+
+		switch c {
+		case ',':
+			p.punct(",")
+			if c, err = p.mustNext(); err != nil {
+				return err
+			}
+		case closer:
+			p.newline(depth)
+			p.punct(string(closer))
+			return nil
+		default:
+			return p.syntaxError(fmt.Sprintf("unexpected %q", c))
+		}
+	}
+}
+
+// key writes an object key and its colon, given the key's first byte, and
+// returns the first byte of the value that follows.
+func (p *printer) key(c byte) (byte, error) {
+	if c != '"' {
+		return 0, p.syntaxError(fmt.Sprintf("unexpected %q, want object key", c))
+	}
+	if err := p.string(p.opts.Styles.Key); err != nil {
+		return 0, err
+	}
+
+	c, err := p.mustNext()
+	if err != nil {
+		return 0, err
+	}
+	if c != ':' {
+		return 0, p.syntaxError(fmt.Sprintf("unexpected %q, want ':'", c))
+	}
+	p.punct(":")
+	p.w.WriteByte(' ') //nolint:errcheck
+
+	return p.mustNext()
+}
+
+// string writes a string whose opening quote has already been read.
+func (p *printer) string(style color.Style) error {
+	var (
+		buf     = []byte{'"'}
+		escaped bool
+	)
+
+	for {
+		c, err := p.r.ReadByte()
+		if err != nil {
+			return p.syntaxError("unterminated string")
+		}
+		p.offset++
+		buf = append(buf, c)
+
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			p.w.WriteString(style.Wrap(string(buf))) //nolint:errcheck
+			return nil
+		}
+	}
+}
+
+// number writes a number whose first byte, c, has already been read.
+func (p *printer) number(c byte) error {
+	num, err := p.scan(c, isNumberByte)
+	if err != nil {
+		return err
+	}
+	p.w.WriteString(p.opts.Styles.Number.Wrap(num)) //nolint:errcheck
+	return nil
+}
+
+// literal writes one of allowed, whose first byte, c, has already been read.
+func (p *printer) literal(c byte, style color.Style, allowed ...string) error {
+	word, err := p.scan(c, isLetter)
+	if err != nil {
+		return err
+	}
+
+	for _, x := range allowed {
+		if word == x {
+			p.w.WriteString(style.Wrap(word)) //nolint:errcheck
+			return nil
+		}
+	}
+
+	return p.syntaxError(fmt.Sprintf("invalid literal %q", word))
+}
+
+// scan returns c followed by each subsequent byte for which accept returns
+// true.
+func (p *printer) scan(c byte, accept func(byte) bool) (string, error) {
+	buf := []byte{c}
+	for {
+		next, err := p.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if !accept(next) {
+			p.r.UnreadByte() //nolint:errcheck
+			break
+		}
+		p.offset++
+		buf = append(buf, next)
+	}
+
+	return string(buf), nil
+}
+
+func (p *printer) punct(str string) {
+	p.w.WriteString(p.opts.Styles.Punct.Wrap(str)) //nolint:errcheck
+}
+
+func (p *printer) newline(depth int) {
+	p.w.WriteByte('\n')            //nolint:errcheck
+	p.w.WriteString(p.opts.Prefix) //nolint:errcheck
+	for i := 0; i < depth; i++ {
+		p.w.WriteString(p.opts.Indent) //nolint:errcheck
+	}
+}
+
+func (p *printer) syntaxError(msg string) error {
+	return errors.Wrap(ErrSyntax, fmt.Sprintf("%s at offset %d", msg, p.offset))
+}
+
+func isNumberByte(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
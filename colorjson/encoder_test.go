@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorjson_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colorjson"
+	"go.mway.dev/color/colortest"
+)
+
+func TestMarshal_NoColor(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	values := []any{
+		nil,
+		true,
+		1.5e300,
+		"<html> & \"quotes\"  ",
+		[]any{},
+		map[string]any{},
+		[]int{1, 2, 3},
+		map[string]any{
+			"a": []any{1, "two", nil, false, map[string]any{}},
+			"b": map[string]any{"c": []any{}, "d": -0.25},
+			"e": json.RawMessage(`{"x" : [ 1 , 2 ]}`),
+		},
+		struct {
+			Name  string `json:"name"`
+			Inner struct {
+				Values []float64 `json:"values"`
+			} `json:"inner"`
+		}{Name: "x"},
+	}
+
+	for _, v := range values {
+		want, err := json.MarshalIndent(v, "", "  ")
+		require.NoError(t, err)
+
+		got, err := colorjson.Marshal(v)
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(got))
+
+		want, err = json.MarshalIndent(v, ">", "\t")
+		require.NoError(t, err)
+
+		got, err = colorjson.Marshal(v, colorjson.WithIndent(">", "\t"))
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(got))
+	}
+}
+
+func TestEncoder_NoColor(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		v    = map[string]any{"a": []int{1}, "b": "c"}
+		want bytes.Buffer
+		got  bytes.Buffer
+	)
+
+	enc := json.NewEncoder(&want)
+	enc.SetIndent("", "  ")
+	require.NoError(t, enc.Encode(v))
+	require.NoError(t, enc.Encode(v))
+
+	cenc := colorjson.NewEncoder(&got)
+	require.NoError(t, cenc.Encode(v))
+	require.NoError(t, cenc.Encode(v))
+
+	require.Equal(t, want.String(), got.String())
+}
+
+func TestEncoder_Styles(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		s   = colorjson.DefaultStyles()
+		p   = color.FgHiBlack
+		buf bytes.Buffer
+		enc = colorjson.NewEncoder(&buf, colorjson.WithStyles(colorjson.Styles{Punct: p}))
+	)
+
+	require.NoError(t, enc.Stream(strings.NewReader(
+		`{"k": ["s", 1, true, null]} []`,
+	)))
+
+	require.Equal(t, strings.Join([]string{
+		p.Wrap("{"),
+		"  " + s.Key.Wrap(`"k"`) + p.Wrap(":") + " " + p.Wrap("["),
+		"    " + s.String.Wrap(`"s"`) + p.Wrap(","),
+		"    " + s.Number.Wrap("1") + p.Wrap(","),
+		"    " + s.Bool.Wrap("true") + p.Wrap(","),
+		"    " + s.Null.Wrap("null"),
+		"  " + p.Wrap("]"),
+		p.Wrap("}"),
+		p.Wrap("[]"),
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestEncoder_Errors(t *testing.T) {
+	cases := map[string]string{
+		`{"a" 1}`:     `unexpected '1', want ':' at offset 6`,
+		`{1: 2}`:      `unexpected '1', want object key at offset 2`,
+		`[1 2]`:       `unexpected '2' at offset 4`,
+		`[1,`:         `unexpected end of input at offset 3`,
+		`"abc`:        `unterminated string at offset 4`,
+		`[nul]`:       `invalid literal "nul" at offset 4`,
+		`}`:           `unexpected '}' at offset 1`,
+		`{"a": tru }`: `invalid literal "tru" at offset 9`,
+	}
+
+	for give, want := range cases {
+		err := colorjson.NewEncoder(&bytes.Buffer{}).Stream(strings.NewReader(give))
+		require.ErrorIs(t, err, colorjson.ErrSyntax, give)
+		require.ErrorContains(t, err, want, give)
+	}
+
+	_, err := colorjson.Marshal(make(chan int))
+	require.Error(t, err)
+
+	_, err = colorjson.Marshal([]any{1, make(chan int)})
+	require.Error(t, err)
+
+	// A failing writer stops the encoder rather than blocking it.
+	big := make([]int, 1<<16)
+	err = colorjson.NewEncoder(errWriter{}).Encode(big)
+	require.ErrorIs(t, err, errWrite)
+}
+
+var errWrite = errors.New("write failed")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestOptions(t *testing.T) {
+	opts := colorjson.DefaultOptions().With(colorjson.Options{
+		Styles: colorjson.Styles{Key: color.FgRed},
+		Prefix: "#",
+		Indent: "\t",
+	})
+
+	require.Equal(t, color.FgRed, opts.Styles.Key)
+	require.Equal(t, color.FgGreen, opts.Styles.String)
+	require.Equal(t, "#", opts.Prefix)
+	require.Equal(t, "\t", opts.Indent)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorjson
+
+import (
+	"go.mway.dev/color"
+)
+
+// Styles are the styles applied to the parts of a JSON document.
+type Styles struct {
+	// Key is applied to object keys, including their quotes.
+	Key color.Style
+	// String is applied to string values, including their quotes.
+	String color.Style
+	// Number is applied to numbers.
+	Number color.Style
+	// Bool is applied to true and false.
+	Bool color.Style
+	// Null is applied to null.
+	Null color.Style
+	// Punct is applied to braces, brackets, colons, and commas.
+	Punct color.Style
+}
+
+// DefaultStyles returns the default [Styles].
+func DefaultStyles() Styles {
+	return Styles{
+		Key:    color.FgBlue,
+		String: color.FgGreen,
+		Number: color.FgCyan,
+		Bool:   color.FgYellow,
+		Null:   color.FgHiBlack,
+		Punct:  color.Nop,
+	}
+}
+
+func (s Styles) merge(dst *Styles) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: s.Key, dst: &dst.Key},
+		{src: s.String, dst: &dst.String},
+		{src: s.Number, dst: &dst.Number},
+		{src: s.Bool, dst: &dst.Bool},
+		{src: s.Null, dst: &dst.Null},
+		{src: s.Punct, dst: &dst.Punct},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Options configure an [Encoder].
+type Options struct {
+	// Styles are the styles applied to the parts of a document. Nil styles
+	// are left unchanged when options are merged.
+	Styles Styles
+	// Prefix begins each line after the first, as in [json.MarshalIndent].
+	Prefix string
+	// Indent is repeated once per level of nesting, as in
+	// [json.MarshalIndent].
+	Indent string
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Styles: DefaultStyles(),
+		Indent: "  ",
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Styles.merge(&dst.Styles)
+	if len(o.Prefix) > 0 {
+		dst.Prefix = o.Prefix
+	}
+	if len(o.Indent) > 0 {
+		dst.Indent = o.Indent
+	}
+}
+
+// An Option configures an [Encoder].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithStyles returns an [Option] that sets the styles applied to the parts of
+// a document. Nil styles in styles are ignored.
+func WithStyles(styles Styles) Option {
+	return optionFunc(func(o *Options) {
+		styles.merge(&o.Styles)
+	})
+}
+
+// WithIndent returns an [Option] that sets the line prefix and indentation,
+// as in [json.MarshalIndent].
+func WithIndent(prefix string, indent string) Option {
+	return optionFunc(func(o *Options) {
+		o.Prefix = prefix
+		o.Indent = indent
+	})
+}
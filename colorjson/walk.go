@@ -0,0 +1,186 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorjson
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// _maxWalkDepth is the depth beyond which values are encoded by
+// [json.Marshal], which reports cycles rather than recursing forever.
+const _maxWalkDepth = 1000
+
+var (
+	_jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	_textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// walk writes the compact JSON encoding of v to w. Maps, slices, arrays,
+// pointers, and interfaces are walked element by element, so that large
+// collections are written as they are encoded; any other value (including
+// structs and values with custom marshalers) is encoded with [json.Marshal].
+// The output is identical to that of [json.Marshal].
+func walk(w *bufio.Writer, v reflect.Value, depth int) error {
+	if !v.IsValid() {
+		_, err := w.WriteString("null")
+		return err
+	}
+	if depth > _maxWalkDepth || hasMarshaler(v.Type()) {
+		return leaf(w, v)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			_, err := w.WriteString("null")
+			return err
+		}
+		return walk(w, v.Elem(), depth+1)
+	case reflect.Slice:
+		if v.IsNil() {
+			_, err := w.WriteString("null")
+			return err
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return leaf(w, v)
+		}
+		return walkArray(w, v, depth)
+	case reflect.Array:
+		return walkArray(w, v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			_, err := w.WriteString("null")
+			return err
+		}
+		return walkMap(w, v, depth)
+	default:
+		return leaf(w, v)
+	}
+}
+
+func walkArray(w *bufio.Writer, v reflect.Value, depth int) error {
+	w.WriteByte('[') //nolint:errcheck
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			w.WriteByte(',') //nolint:errcheck
+		}
+		if err := walk(w, v.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte(']')
+}
+
+func walkMap(w *bufio.Writer, v reflect.Value, depth int) error {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+
+	var (
+		entries = make([]entry, 0, v.Len())
+		iter    = v.MapRange()
+	)
+	for iter.Next() {
+		key, ok := mapKey(iter.Key())
+		if !ok {
+			// Let json.Marshal encode (or reject) other key types.
+			return leaf(w, v)
+		}
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	w.WriteByte('{') //nolint:errcheck
+	for i, e := range entries {
+		if i > 0 {
+			w.WriteByte(',') //nolint:errcheck
+		}
+		key, err := json.Marshal(e.key)
+		if err != nil {
+			return err
+		}
+		w.Write(key)     //nolint:errcheck
+		w.WriteByte(':') //nolint:errcheck
+		if err := walk(w, e.value, depth+1); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte('}')
+}
+
+// mapKey returns the object key for the map key k, as [json.Marshal] would,
+// or false if k is neither a string nor an integer.
+func mapKey(k reflect.Value) (string, bool) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if hasMarshaler(k.Type()) {
+			return "", false
+		}
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		if hasMarshaler(k.Type()) {
+			return "", false
+		}
+		return strconv.FormatUint(k.Uint(), 10), true
+	default:
+		return "", false
+	}
+}
+
+// leaf writes the encoding of v by [json.Marshal]. Addressable values are
+// marshaled by pointer so that methods with pointer receivers are used, as
+// [json.Marshal] does for values it walks itself.
+func leaf(w *bufio.Writer, v reflect.Value) error {
+	x := v.Interface()
+	if v.CanAddr() {
+		x = v.Addr().Interface()
+	}
+
+	data, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// hasMarshaler reports whether t or a pointer to t implements [json.Marshaler]
+// or [encoding.TextMarshaler].
+func hasMarshaler(t reflect.Type) bool {
+	for _, m := range []reflect.Type{_jsonMarshalerType, _textMarshalerType} {
+		if t.Implements(m) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(m)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ptrMarshaler struct{ n int }
+
+func (p *ptrMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.n * 2)
+}
+
+type textKey int
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte("key"), nil
+}
+
+type cyclic struct {
+	Next *cyclic
+}
+
+func TestWalk(t *testing.T) {
+	str := "s"
+	cases := map[string]any{
+		"nil":          nil,
+		"string":       "a<b>& ",
+		"numbers":      []any{1, -2.5, uint8(3), 1e21},
+		"nil slice":    []int(nil),
+		"nil map":      map[string]int(nil),
+		"nil pointer":  (*int)(nil),
+		"pointer":      &str,
+		"bytes":        []byte("hello"),
+		"byte array":   [3]byte{1, 2, 3},
+		"sorted map":   map[string]any{"b": 1, "a": []string{"x"}, "<": nil},
+		"int keys":     map[int]bool{10: true, -1: false, 2: true},
+		"uint keys":    map[uint16]string{3: "c", 1: "a"},
+		"text keys":    map[textKey]int{1: 1},
+		"other keys":   map[float64]int{1.5: 1},
+		"struct":       struct{ A, b int }{A: 1, b: 2},
+		"nested":       map[string][]map[string]any{"x": {{"y": []any{nil, true}}}},
+		"time":         []time.Time{time.Unix(0, 0).UTC()},
+		"raw":          []json.RawMessage{json.RawMessage(`{"a":1}`)},
+		"ptr receiver": []ptrMarshaler{{n: 1}, {n: 2}},
+		"ptr value":    map[string]ptrMarshaler{"a": {n: 1}},
+	}
+
+	for name, v := range cases {
+		t.Run(name, func(t *testing.T) {
+			want, err := json.Marshal(v)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			w := bufio.NewWriter(&buf)
+			require.NoError(t, walk(w, reflect.ValueOf(v), 0))
+			require.NoError(t, w.Flush())
+			require.Equal(t, string(want), buf.String())
+		})
+	}
+}
+
+func TestWalk_Errors(t *testing.T) {
+	c := &cyclic{}
+	c.Next = c
+
+	for name, v := range map[string]any{
+		"chan":      []any{make(chan int)},
+		"cycle":     c,
+		"map cycle": map[string]any{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if m, ok := v.(map[string]any); ok {
+				m["m"] = m
+			}
+
+			_, wantErr := json.Marshal(v)
+			require.Error(t, wantErr)
+
+			var unsupported *json.UnsupportedTypeError
+			err := walk(bufio.NewWriter(&bytes.Buffer{}), reflect.ValueOf(v), 0)
+			require.Error(t, err)
+			require.Equal(t, errors.As(wantErr, &unsupported), errors.As(err, &unsupported))
+		})
+	}
+}
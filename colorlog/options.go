@@ -0,0 +1,171 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorlog
+
+import (
+	"log"
+
+	"go.mway.dev/color"
+)
+
+// ColorMode controls whether a [Writer] writes styled output.
+type ColorMode int
+
+// Color modes.
+const (
+	// ColorAuto styles output if color is enabled for the writer's
+	// destination (see [color.EnabledFor]).
+	ColorAuto ColorMode = iota
+	// ColorAlways always styles output.
+	ColorAlways
+	// ColorNever never styles output.
+	ColorNever
+)
+
+// Styles are the styles applied to the parts of a log line.
+type Styles struct {
+	// Prefix is applied to the logger's prefix.
+	Prefix color.Style
+	// Date is applied to the date, if present.
+	Date color.Style
+	// Time is applied to the time, if present.
+	Time color.Style
+	// Source is applied to the file and line number, if present.
+	Source color.Style
+	// Message is applied to the message, including any continuation lines
+	// of multi-line messages.
+	Message color.Style
+}
+
+// DefaultStyles returns the default [Styles].
+func DefaultStyles() Styles {
+	return Styles{
+		Prefix:  color.FgCyan.With(color.Bold),
+		Date:    color.FgHiBlack,
+		Time:    color.FgHiBlack,
+		Source:  color.FgYellow,
+		Message: color.Nop,
+	}
+}
+
+func (s Styles) merge(dst *Styles) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: s.Prefix, dst: &dst.Prefix},
+		{src: s.Date, dst: &dst.Date},
+		{src: s.Time, dst: &dst.Time},
+		{src: s.Source, dst: &dst.Source},
+		{src: s.Message, dst: &dst.Message},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Options configure a [Writer].
+type Options struct {
+	// Styles are the styles applied to the parts of a log line. Nil styles
+	// are left unchanged when options are merged.
+	Styles Styles
+	// Prefix is the prefix of the [log.Logger] writing to the writer. It
+	// must match the logger's prefix to be styled.
+	Prefix string
+	// Flags are the flags of the [log.Logger] writing to the writer (see
+	// [log.Flags]). They must match the logger's flags for lines to be
+	// parsed correctly.
+	Flags int
+	// Color controls whether output is styled.
+	Color ColorMode
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Styles: DefaultStyles(),
+		Flags:  log.LstdFlags,
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Styles.merge(&dst.Styles)
+	if len(o.Prefix) > 0 {
+		dst.Prefix = o.Prefix
+	}
+	if o.Flags != 0 {
+		dst.Flags = o.Flags
+	}
+	if o.Color != ColorAuto {
+		dst.Color = o.Color
+	}
+}
+
+// An Option configures a [Writer].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithStyles returns an [Option] that sets the styles applied to the parts of
+// a log line. Nil styles in styles are ignored.
+func WithStyles(styles Styles) Option {
+	return optionFunc(func(o *Options) {
+		styles.merge(&o.Styles)
+	})
+}
+
+// WithPrefix returns an [Option] that sets the prefix of the [log.Logger]
+// writing to the writer.
+func WithPrefix(prefix string) Option {
+	return optionFunc(func(o *Options) {
+		o.Prefix = prefix
+	})
+}
+
+// WithFlags returns an [Option] that sets the flags of the [log.Logger]
+// writing to the writer. Unlike [Options.Flags], zero flags are honored.
+func WithFlags(flags int) Option {
+	return optionFunc(func(o *Options) {
+		o.Flags = flags
+	})
+}
+
+// WithColor returns an [Option] that controls whether output is styled.
+func WithColor(mode ColorMode) Option {
+	return optionFunc(func(o *Options) {
+		o.Color = mode
+	})
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package colorlog provides an [io.Writer] that styles the output of a
+// standard library [log.Logger].
+package colorlog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"go.mway.dev/color"
+)
+
+const (
+	_dateLayout   = "9999/99/99"
+	_timeLayout   = "99:99:99"
+	_microsLayout = ".999999"
+)
+
+var _ io.Writer = (*Writer)(nil)
+
+// A Writer is an [io.Writer] that styles the lines written by a [log.Logger],
+// e.g. via [log.SetOutput]. Each line is parsed according to the writer's
+// prefix and flags, which must match the logger's, and its prefix, date, time,
+// source, and message are styled individually. Lines that do not start with a
+// log header, such as continuation lines of multi-line messages, are styled as
+// messages.
+//
+// Partial writes are buffered until a newline is written or the writer is
+// flushed. Whether output is styled is decided once, when the writer is
+// created, based on its destination; unstyled output is passed through
+// unchanged.
+type Writer struct {
+	opts   Options
+	w      io.Writer
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	styled bool
+}
+
+// NewWriter creates a new [Writer] that writes to w.
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	options := DefaultOptions().With(opts...)
+	return &Writer{
+		opts: options,
+		w:    w,
+		styled: options.Color == ColorAlways ||
+			(options.Color == ColorAuto && color.EnabledFor(w)),
+	}
+}
+
+// NewLogger creates a new [log.Logger] with the given prefix and flags that
+// writes to w via a [Writer] configured to match.
+func NewLogger(w io.Writer, prefix string, flags int, opts ...Option) *log.Logger {
+	opts = append(opts, WithPrefix(prefix), WithFlags(flags))
+	return log.New(NewWriter(w, opts...), prefix, flags)
+}
+
+// Write writes the complete lines in p, styled, to the underlying writer, and
+// buffers any trailing partial line.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.styled {
+		return w.w.Write(p)
+	}
+
+	w.buf.Write(p)
+
+	idx := bytes.LastIndexByte(w.buf.Bytes(), '\n')
+	if idx < 0 {
+		return len(p), nil
+	}
+
+	var (
+		lines = string(w.buf.Next(idx + 1))
+		out   strings.Builder
+	)
+
+	for _, line := range strings.SplitAfter(lines, "\n") {
+		if len(line) > 0 {
+			w.format(&out, line)
+		}
+	}
+
+	if _, err := io.WriteString(w.w, out.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered partial line to the underlying writer.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() == 0 {
+		return nil
+	}
+
+	var out strings.Builder
+	w.format(&out, w.buf.String())
+	w.buf.Reset()
+
+	_, err := io.WriteString(w.w, out.String())
+	return err
+}
+
+// format writes line, which may end with a newline, to out with styles
+// applied.
+func (w *Writer) format(out *strings.Builder, line string) {
+	var (
+		styles = w.opts.Styles
+		rest   = strings.TrimSuffix(line, "\n")
+		header strings.Builder
+		ok     bool
+	)
+
+	if rest, ok = w.header(&header, rest); ok {
+		out.WriteString(header.String())
+	} else {
+		rest = strings.TrimSuffix(line, "\n")
+	}
+
+	if len(rest) > 0 {
+		out.WriteString(paint(styles.Message, rest))
+	}
+	if strings.HasSuffix(line, "\n") {
+		out.WriteByte('\n')
+	}
+}
+
+// header writes the styled log header at the start of line to out and
+// returns the remainder of line. It reports false if line does not start
+// with a header matching the writer's prefix and flags.
+func (w *Writer) header(out *strings.Builder, line string) (string, bool) {
+	var (
+		styles    = w.opts.Styles
+		flags     = w.opts.Flags
+		prefix    = w.opts.Prefix
+		msgprefix = flags&log.Lmsgprefix != 0
+		field     string
+		ok        bool
+	)
+
+	if !msgprefix && len(prefix) > 0 {
+		if !strings.HasPrefix(line, prefix) {
+			return line, false
+		}
+		out.WriteString(paint(styles.Prefix, prefix))
+		line = line[len(prefix):]
+	}
+
+	if flags&log.Ldate != 0 {
+		if field, line, ok = cut(line, _dateLayout); !ok {
+			return line, false
+		}
+		out.WriteString(paint(styles.Date, field) + " ")
+	}
+
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout := _timeLayout
+		if flags&log.Lmicroseconds != 0 {
+			layout += _microsLayout
+		}
+		if field, line, ok = cut(line, layout); !ok {
+			return line, false
+		}
+		out.WriteString(paint(styles.Time, field) + " ")
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		idx := strings.Index(line, ": ")
+		if idx < 0 || !isSource(line[:idx]) {
+			return line, false
+		}
+		out.WriteString(paint(styles.Source, line[:idx+1]) + " ")
+		line = line[idx+2:]
+	}
+
+	if msgprefix && len(prefix) > 0 {
+		if !strings.HasPrefix(line, prefix) {
+			return line, false
+		}
+		out.WriteString(paint(styles.Prefix, prefix))
+		line = line[len(prefix):]
+	}
+
+	return line, true
+}
+
+// cut matches the start of str against layout, in which '9' matches any
+// digit and other bytes match themselves, followed by a space. It returns
+// the matched field and the remainder of str after the space.
+func cut(str string, layout string) (string, string, bool) {
+	if len(str) <= len(layout) || str[len(layout)] != ' ' {
+		return "", str, false
+	}
+
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == '9' {
+			if str[i] < '0' || str[i] > '9' {
+				return "", str, false
+			}
+		} else if str[i] != layout[i] {
+			return "", str, false
+		}
+	}
+
+	return str[:len(layout)], str[len(layout)+1:], true
+}
+
+// isSource reports whether str has the form "file:line".
+func isSource(str string) bool {
+	idx := strings.LastIndexByte(str, ':')
+	if idx <= 0 || idx == len(str)-1 {
+		return false
+	}
+
+	for _, r := range str[idx+1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func paint(style color.Style, str string) string {
+	if esc := style.String(); len(esc) > 0 {
+		return esc + str + color.Reset.String()
+	}
+	return str
+}
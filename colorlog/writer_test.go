@@ -0,0 +1,205 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorlog_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colorlog"
+)
+
+func paint(style color.Style, str string) string {
+	if style == color.Nop {
+		return str
+	}
+	return style.String() + str + color.Reset.String()
+}
+
+func TestWriter_Styled(t *testing.T) {
+	styles := colorlog.DefaultStyles()
+
+	cases := map[string]struct {
+		prefix string
+		flags  int
+		input  string
+		want   string
+	}{
+		"no header": {
+			input: "hello\n",
+			want:  "hello\n",
+		},
+		"standard": {
+			flags: log.LstdFlags,
+			input: "2024/01/02 03:04:05 hello\n",
+			want: paint(styles.Date, "2024/01/02") + " " +
+				paint(styles.Time, "03:04:05") + " hello\n",
+		},
+		"all": {
+			prefix: "app: ",
+			flags:  log.Ldate | log.Lmicroseconds | log.Lshortfile,
+			input:  "app: 2024/01/02 03:04:05.123456 main.go:12: hello\n",
+			want: paint(styles.Prefix, "app: ") +
+				paint(styles.Date, "2024/01/02") + " " +
+				paint(styles.Time, "03:04:05.123456") + " " +
+				paint(styles.Source, "main.go:12:") + " hello\n",
+		},
+		"msgprefix": {
+			prefix: "[x] ",
+			flags:  log.Llongfile | log.Lmsgprefix,
+			input:  "/src/main.go:7: [x] hello\n",
+			want: paint(styles.Source, "/src/main.go:7:") + " " +
+				paint(styles.Prefix, "[x] ") + "hello\n",
+		},
+		"multiline": {
+			flags: log.Ltime,
+			input: "03:04:05 first\nsecond\n\n",
+			want:  paint(styles.Time, "03:04:05") + " first\nsecond\n\n",
+		},
+		"mismatch": {
+			prefix: "app: ",
+			flags:  log.Ldate | log.Lshortfile,
+			input:  "other: 2024/01/02 x\napp: 2024/1/02 x\napp: 2024/01/02 x: y\n",
+			want:   "other: 2024/01/02 x\napp: 2024/1/02 x\napp: 2024/01/02 x: y\n",
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				buf bytes.Buffer
+				w   = colorlog.NewWriter(
+					&buf,
+					colorlog.WithPrefix(tt.prefix),
+					colorlog.WithFlags(tt.flags),
+					colorlog.WithColor(colorlog.ColorAlways),
+					colorlog.WithStyles(colorlog.Styles{Message: color.Nop}),
+				)
+			)
+
+			n, err := w.Write([]byte(tt.input))
+			require.NoError(t, err)
+			require.Equal(t, len(tt.input), n)
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriter_PartialWrites(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		styles = colorlog.DefaultStyles()
+		w      = colorlog.NewWriter(
+			&buf,
+			colorlog.WithFlags(log.Ltime),
+			colorlog.WithColor(colorlog.ColorAlways),
+			colorlog.WithStyles(colorlog.Styles{Message: color.Bold}),
+		)
+		input = "03:04:05 one\n03:04:06 two\n03:04:07 three"
+	)
+
+	for i := range input {
+		_, err := w.Write([]byte{input[i]})
+		require.NoError(t, err)
+	}
+
+	want := paint(styles.Time, "03:04:05") + " " + paint(color.Bold, "one") + "\n" +
+		paint(styles.Time, "03:04:06") + " " + paint(color.Bold, "two") + "\n"
+	require.Equal(t, want, buf.String())
+
+	require.NoError(t, w.Flush())
+	want += paint(styles.Time, "03:04:07") + " " + paint(color.Bold, "three")
+	require.Equal(t, want, buf.String())
+
+	require.NoError(t, w.Flush())
+	require.Equal(t, want, buf.String())
+}
+
+func TestWriter_Unstyled(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		w   = colorlog.NewWriter(&buf, colorlog.WithColor(colorlog.ColorNever))
+	)
+
+	_, err := w.Write([]byte("2024/01/02 03:04:05 partial"))
+	require.NoError(t, err)
+	require.Equal(t, "2024/01/02 03:04:05 partial", buf.String())
+	require.NoError(t, w.Flush())
+}
+
+func TestWriter_Error(t *testing.T) {
+	w := colorlog.NewWriter(errWriter{}, colorlog.WithColor(colorlog.ColorAlways))
+
+	n, err := w.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.Equal(t, 7, n)
+
+	_, err = w.Write([]byte("\n"))
+	require.ErrorIs(t, err, errTest)
+}
+
+func TestNewLogger(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		styles = colorlog.DefaultStyles()
+		logger = colorlog.NewLogger(
+			&buf,
+			"svc ",
+			log.Lmsgprefix,
+			colorlog.WithColor(colorlog.ColorAlways),
+		)
+	)
+
+	logger.Print("a\nb")
+	require.Equal(
+		t,
+		paint(styles.Prefix, "svc ")+paint(styles.Message, "a")+"\n"+
+			paint(styles.Message, "b")+"\n",
+		buf.String(),
+	)
+}
+
+func TestOptions(t *testing.T) {
+	opts := colorlog.DefaultOptions().With(colorlog.Options{
+		Styles: colorlog.Styles{Date: color.FgRed},
+		Prefix: "x",
+		Flags:  log.Lshortfile,
+		Color:  colorlog.ColorNever,
+	})
+
+	require.Equal(t, color.FgRed, opts.Styles.Date)
+	require.Equal(t, color.FgHiBlack, opts.Styles.Time)
+	require.Equal(t, "x", opts.Prefix)
+	require.Equal(t, log.Lshortfile, opts.Flags)
+	require.Equal(t, colorlog.ColorNever, opts.Color)
+}
+
+var errTest = errors.New("test")
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errTest
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package colorslog provides a [slog.Handler] that writes human-friendly,
+// styled log lines.
+package colorslog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mway.dev/color"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// A Handler is a [slog.Handler] that writes each record as a single line of
+// the form:
+//
+//	TIME LEVEL SOURCE MESSAGE KEY=VALUE...
+//
+// Attributes within groups are written with their keys qualified by the
+// group names, e.g. "request.id=1". Messages and keys that contain newlines or
+// other control characters are quoted, as are values that contain spaces, so
+// that each record is written as exactly one line. Whether output is styled is decided once,
+// when the handler is created, based on its writer.
+type Handler struct {
+	opts   Options
+	w      io.Writer
+	mu     *sync.Mutex
+	styled bool
+	prefix string
+	attrs  string
+}
+
+// NewHandler creates a new [Handler] that writes to w.
+func NewHandler(w io.Writer, opts ...Option) *Handler {
+	options := DefaultOptions().With(opts...)
+	return &Handler{
+		opts: options,
+		w:    w,
+		mu:   new(sync.Mutex),
+		styled: options.Color == ColorAlways ||
+			(options.Color == ColorAuto && color.EnabledFor(w)),
+	}
+}
+
+// Enabled reports whether h handles records at the given level.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle writes r.
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	var (
+		styles = h.opts.Styles
+		buf    strings.Builder
+	)
+
+	if !r.Time.IsZero() && len(h.opts.TimeFormat) > 0 {
+		buf.WriteString(h.paint(styles.Time, r.Time.Format(h.opts.TimeFormat)))
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(h.paint(styles.level(r.Level), fmt.Sprintf("%-5s", r.Level.String())))
+	buf.WriteByte(' ')
+
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		source := filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		buf.WriteString(h.paint(styles.Source, source))
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(h.paint(styles.Message, quoteControl(r.Message)))
+	buf.WriteString(h.attrs)
+	r.Attrs(func(attr slog.Attr) bool {
+		h.appendAttr(&buf, h.prefix, attr)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.w, buf.String())
+	return err
+}
+
+// WithAttrs returns a new [Handler] whose records include attrs.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	var buf strings.Builder
+	buf.WriteString(h.attrs)
+	for _, attr := range attrs {
+		h.appendAttr(&buf, h.prefix, attr)
+	}
+
+	clone := *h
+	clone.attrs = buf.String()
+	return &clone
+}
+
+// WithGroup returns a new [Handler] that qualifies subsequent attributes with
+// name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+
+	clone := *h
+	clone.prefix += name + "."
+	return &clone
+}
+
+func (h *Handler) appendAttr(buf *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if len(attr.Key) > 0 {
+			prefix += attr.Key + "."
+		}
+		for _, x := range attr.Value.Group() {
+			h.appendAttr(buf, prefix, x)
+		}
+		return
+	}
+
+	var (
+		styles = h.opts.Styles
+		style  = styles.Value
+	)
+
+	if _, ok := attr.Value.Any().(error); ok {
+		style = styles.ErrorValue
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(h.paint(styles.Key, quoteControl(prefix+attr.Key)+"="))
+	buf.WriteString(h.paint(style, formatValue(attr.Value)))
+}
+
+// paint wraps str in style if h writes styled output. Unlike
+// [color.Style.Wrap], this does not depend on [color.Enabled].
+func (h *Handler) paint(style color.Style, str string) string {
+	if !h.styled {
+		return str
+	}
+
+	esc := style.String()
+	if len(esc) == 0 {
+		return str
+	}
+	return esc + str + color.Reset.String()
+}
+
+func formatValue(v slog.Value) string {
+	var str string
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format("2006-01-02T15:04:05.000Z07:00")
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			str = err.Error()
+		} else {
+			str = v.String()
+		}
+	default:
+		str = v.String()
+	}
+
+	if needsQuoting(str) {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+// quoteControl returns str quoted as by [strconv.Quote] if it contains
+// control characters, such as newlines or escape sequences, or invalid UTF-8,
+// and str itself otherwise.
+func quoteControl(str string) string {
+	for _, r := range str {
+		if r == utf8.RuneError || (r != ' ' && !unicode.IsPrint(r)) {
+			return strconv.Quote(str)
+		}
+	}
+	return str
+}
+
+func needsQuoting(str string) bool {
+	if len(str) == 0 {
+		return true
+	}
+	for _, r := range str {
+		if unicode.IsSpace(r) || r == '"' || r == '=' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorslog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colorslog"
+	"go.mway.dev/color/colortest"
+)
+
+var _epoch = time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+func newRecord(level slog.Level, msg string, attrs ...slog.Attr) slog.Record {
+	r := slog.NewRecord(_epoch, level, msg, 0)
+	r.AddAttrs(attrs...)
+	return r
+}
+
+func TestHandler_Plain(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		h   = colorslog.NewHandler(&buf, colorslog.WithLevel(slog.LevelDebug))
+	)
+
+	require.NoError(t, h.Handle(context.Background(), newRecord(
+		slog.LevelInfo,
+		"hello world",
+		slog.String("name", "a b"),
+		slog.Int("n", 3),
+		slog.Any("err", errors.New("boom")),
+		slog.Any("", nil),
+		slog.Time("at", _epoch),
+		slog.Duration("took", time.Second),
+		slog.Group("req", slog.String("id", "x"), slog.Group("empty")),
+		slog.Group("", slog.Bool("inline", true)),
+	)))
+
+	require.Equal(
+		t,
+		`03:04:05.006 INFO  hello world name="a b" n=3 err=boom `+
+			`at=2024-01-02T03:04:05.006Z took=1s req.id=x inline=true`+"\n",
+		buf.String(),
+	)
+}
+
+func TestHandler_WithAttrsGroups(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(colorslog.NewHandler(&buf, colorslog.WithTimeFormat(time.Kitchen))).
+		With("a", 1).
+		WithGroup("g").
+		With("b", 2).
+		WithGroup("h").
+		WithGroup("")
+
+	logger.Warn("msg", "c", "=")
+	logger.Debug("ignored")
+
+	line := buf.String()
+	require.True(t, strings.HasSuffix(line, ` WARN  msg a=1 g.b=2 g.h.c="="`+"\n"), line)
+}
+
+func TestHandler_Styled(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		styles = colorslog.DefaultStyles()
+		h      = colorslog.NewHandler(
+			&buf,
+			colorslog.WithColor(colorslog.ColorAlways),
+			colorslog.WithTimeFormat("15:04"),
+			colorslog.WithStyles(colorslog.Styles{Message: color.Underline}),
+		)
+		paint = func(style color.Style, str string) string {
+			return style.String() + str + color.Reset.String()
+		}
+	)
+
+	// Styling does not depend on whether color is globally enabled.
+	colortest.ForceColor(t, false)
+
+	require.NoError(t, h.Handle(context.Background(), newRecord(
+		slog.LevelError,
+		"failed",
+		slog.Any("err", errors.New("x")),
+		slog.String("k", "v"),
+	)))
+
+	require.Equal(
+		t,
+		paint(styles.Time, "03:04")+" "+
+			paint(styles.Error, "ERROR")+" "+
+			paint(color.Underline, "failed")+" "+
+			paint(styles.Key, "err=")+paint(styles.ErrorValue, "x")+" "+
+			paint(styles.Key, "k=")+"v\n",
+		buf.String(),
+	)
+}
+
+func TestHandler_Quoting(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		h   = colorslog.NewHandler(&buf, colorslog.WithTimeFormat("-"))
+	)
+
+	require.NoError(t, h.Handle(context.Background(), newRecord(
+		slog.LevelInfo,
+		"ok\n- ERROR forged\x1b[2J",
+		slog.String("a\nb", "c\nd"),
+		slog.String("bad", "\xff"),
+	)))
+	require.NoError(t, h.Handle(context.Background(), newRecord(
+		slog.LevelInfo,
+		"plain message",
+		slog.String("key", "héllo"),
+	)))
+
+	require.Equal(
+		t,
+		`- INFO  "ok\n- ERROR forged\x1b[2J" "a\nb"="c\nd" bad="\xff"`+"\n"+
+			`- INFO  plain message key=héllo`+"\n",
+		buf.String(),
+	)
+}
+
+func TestHandler_Source(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slog.New(colorslog.NewHandler(
+		&buf,
+		colorslog.WithSource(true),
+		colorslog.WithColor(colorslog.ColorNever),
+	))
+	logger.Info("here")
+
+	require.Regexp(t, `^\S+ INFO  handler_test\.go:\d+ here\n$`, buf.String())
+}
+
+func TestHandler_Levels(t *testing.T) {
+	var (
+		buf    bytes.Buffer
+		styles = colorslog.DefaultStyles()
+		h      = colorslog.NewHandler(
+			&buf,
+			colorslog.WithColor(colorslog.ColorAlways),
+			colorslog.WithLevel(slog.LevelDebug),
+		)
+	)
+
+	cases := map[slog.Level]color.Style{
+		slog.LevelDebug:     styles.Debug,
+		slog.LevelInfo:      styles.Info,
+		slog.LevelWarn:      styles.Warn,
+		slog.LevelError:     styles.Error,
+		slog.LevelError + 4: styles.Error,
+	}
+
+	for level, style := range cases {
+		buf.Reset()
+		require.True(t, h.Enabled(context.Background(), level))
+		require.NoError(t, h.Handle(context.Background(), slog.NewRecord(time.Time{}, level, "", 0)))
+		require.True(t, strings.HasPrefix(buf.String(), style.String()), level.String())
+	}
+
+	require.False(t, colorslog.NewHandler(&buf).Enabled(context.Background(), slog.LevelDebug))
+
+	h = colorslog.NewHandler(&buf, colorslog.WithLevel(nil))
+	require.False(t, h.Enabled(context.Background(), slog.LevelDebug))
+	require.True(t, h.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestOptions(t *testing.T) {
+	opts := colorslog.DefaultOptions().With(colorslog.Options{
+		Styles:     colorslog.Styles{Key: color.FgRed},
+		Level:      slog.LevelWarn,
+		TimeFormat: time.RFC3339,
+		AddSource:  true,
+		Color:      colorslog.ColorNever,
+	})
+
+	require.Equal(t, color.FgRed, opts.Styles.Key)
+	require.Equal(t, color.FgRed, opts.Styles.ErrorValue)
+	require.Equal(t, color.FgHiRed, opts.Styles.Error)
+	require.Equal(t, color.FgGreen, opts.Styles.Info)
+	require.Equal(t, slog.LevelWarn, opts.Level)
+	require.Equal(t, time.RFC3339, opts.TimeFormat)
+	require.True(t, opts.AddSource)
+	require.Equal(t, colorslog.ColorNever, opts.Color)
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colorslog
+
+import (
+	"log/slog"
+
+	"go.mway.dev/color"
+)
+
+// ColorMode controls whether a [Handler] writes styled output.
+type ColorMode int
+
+// Color modes.
+const (
+	// ColorAuto styles output if color is enabled for the handler's writer
+	// (see [color.EnabledFor]).
+	ColorAuto ColorMode = iota
+	// ColorAlways always styles output.
+	ColorAlways
+	// ColorNever never styles output.
+	ColorNever
+)
+
+// Styles are the styles applied to the parts of a log line.
+type Styles struct {
+	// Time is applied to the record's time.
+	Time color.Style
+	// Debug is applied to the level of records below [slog.LevelInfo].
+	Debug color.Style
+	// Info is applied to the level of records below [slog.LevelWarn].
+	Info color.Style
+	// Warn is applied to the level of records below [slog.LevelError].
+	Warn color.Style
+	// Error is applied to the level of records at or above
+	// [slog.LevelError].
+	Error color.Style
+	// Source is applied to the record's source location.
+	Source color.Style
+	// Message is applied to the record's message.
+	Message color.Style
+	// Key is applied to attribute keys and their separators.
+	Key color.Style
+	// Value is applied to attribute values.
+	Value color.Style
+	// ErrorValue is applied to attribute values that are errors, in place of
+	// Value.
+	ErrorValue color.Style
+}
+
+// DefaultStyles returns the default [Styles].
+func DefaultStyles() Styles {
+	return Styles{
+		Time:       color.FgHiBlack,
+		Debug:      color.FgMagenta,
+		Info:       color.FgGreen,
+		Warn:       color.FgYellow,
+		Error:      color.FgHiRed,
+		Source:     color.FgHiBlack,
+		Message:    color.Bold,
+		Key:        color.FgCyan,
+		Value:      color.Nop,
+		ErrorValue: color.FgRed,
+	}
+}
+
+func (s Styles) merge(dst *Styles) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: s.Time, dst: &dst.Time},
+		{src: s.Debug, dst: &dst.Debug},
+		{src: s.Info, dst: &dst.Info},
+		{src: s.Warn, dst: &dst.Warn},
+		{src: s.Error, dst: &dst.Error},
+		{src: s.Source, dst: &dst.Source},
+		{src: s.Message, dst: &dst.Message},
+		{src: s.Key, dst: &dst.Key},
+		{src: s.Value, dst: &dst.Value},
+		{src: s.ErrorValue, dst: &dst.ErrorValue},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// level returns the style for the given level.
+func (s Styles) level(level slog.Level) color.Style {
+	switch {
+	case level >= slog.LevelError:
+		return s.Error
+	case level >= slog.LevelWarn:
+		return s.Warn
+	case level >= slog.LevelInfo:
+		return s.Info
+	default:
+		return s.Debug
+	}
+}
+
+// Options configure a [Handler].
+type Options struct {
+	// Styles are the styles applied to the parts of a log line. Nil styles
+	// are left unchanged when options are merged.
+	Styles Styles
+	// Level is the minimum level of records that are handled.
+	Level slog.Leveler
+	// TimeFormat is the layout used to format record times. Records with a
+	// zero time are written without one.
+	TimeFormat string
+	// AddSource controls whether the source location of each record is
+	// written.
+	AddSource bool
+	// Color controls whether output is styled.
+	Color ColorMode
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Styles:     DefaultStyles(),
+		Level:      slog.LevelInfo,
+		TimeFormat: "15:04:05.000",
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Styles.merge(&dst.Styles)
+	if o.Level != nil {
+		dst.Level = o.Level
+	}
+	if len(o.TimeFormat) > 0 {
+		dst.TimeFormat = o.TimeFormat
+	}
+	if o.AddSource {
+		dst.AddSource = o.AddSource
+	}
+	if o.Color != ColorAuto {
+		dst.Color = o.Color
+	}
+}
+
+// An Option configures a [Handler].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithStyles returns an [Option] that sets the styles applied to the parts of
+// a log line. Nil styles in styles are ignored.
+func WithStyles(styles Styles) Option {
+	return optionFunc(func(o *Options) {
+		styles.merge(&o.Styles)
+	})
+}
+
+// WithLevel returns an [Option] that sets the minimum level of records that
+// are handled. A nil level is ignored.
+func WithLevel(level slog.Leveler) Option {
+	return optionFunc(func(o *Options) {
+		if level != nil {
+			o.Level = level
+		}
+	})
+}
+
+// WithTimeFormat returns an [Option] that sets the layout used to format
+// record times.
+func WithTimeFormat(layout string) Option {
+	return optionFunc(func(o *Options) {
+		o.TimeFormat = layout
+	})
+}
+
+// WithSource returns an [Option] that controls whether the source location of
+// each record is written.
+func WithSource(enabled bool) Option {
+	return optionFunc(func(o *Options) {
+		o.AddSource = enabled
+	})
+}
+
+// WithColor returns an [Option] that controls whether output is styled.
+func WithColor(mode ColorMode) Option {
+	return optionFunc(func(o *Options) {
+		o.Color = mode
+	})
+}
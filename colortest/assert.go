@@ -0,0 +1,106 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colortest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+)
+
+// _update controls whether [RequireGolden] updates golden files rather than
+// comparing against them, e.g. "go test ./... -colortest.update".
+var _update = flag.Bool("colortest.update", false, "update colortest golden files")
+
+// ForceColor enables or disables color for the duration of t, restoring the
+// previous setting, including automatic detection, when t finishes (see
+// [color.OverrideEnabled]). Tests that use it must not be run in parallel
+// with other tests that depend on color.
+func ForceColor(t testing.TB, enabled bool) {
+	t.Helper()
+
+	t.Cleanup(color.OverrideEnabled(enabled))
+}
+
+// RequireRender requires that str renders as want (see [Render]).
+func RequireRender(t testing.TB, want string, str string) {
+	t.Helper()
+	require.Equal(t, want, Render(str))
+}
+
+// RequireStyleAt requires that the i-th rune of str's visible text is
+// styled with exactly s (see [StyleAt]).
+func RequireStyleAt(t testing.TB, str string, i int, s color.Style) {
+	t.Helper()
+
+	got, ok := StyleAt(str, i)
+	require.True(t, ok, "visible text %q has no rune %d", color.Strip(str), i)
+	require.Equal(t, StyleOf(s), got, "style of rune %d of %q", i, color.Strip(str))
+}
+
+// RequireStyled requires that the first occurrence of substr in str's visible
+// text is styled with exactly s throughout (see [StyleAt]).
+func RequireStyled(t testing.TB, str string, substr string, s color.Style) {
+	t.Helper()
+
+	var (
+		text = color.Strip(str)
+		idx  = strings.Index(text, substr)
+	)
+	require.GreaterOrEqual(t, idx, 0, "visible text %q does not contain %q", text, substr)
+
+	var (
+		want  = StyleOf(s)
+		start = utf8.RuneCountInString(text[:idx])
+		all   = styles(str)
+	)
+	for i := 0; i < utf8.RuneCountInString(substr); i++ {
+		require.Equal(t, want, all[start+i], "style of %q in %q", substr, text)
+	}
+}
+
+// RequireGolden requires that str renders (see [Render]) as the contents of
+// the golden file testdata/name.golden. If the -colortest.update flag is
+// set, the golden file is written instead.
+func RequireGolden(t testing.TB, name string, str string) {
+	t.Helper()
+
+	var (
+		path = filepath.Join("testdata", name+".golden")
+		got  = Render(str)
+	)
+
+	if *_update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644)) //nolint:gosec
+		return
+	}
+
+	want, err := os.ReadFile(path) //nolint:gosec
+	require.NoError(t, err, "run with -colortest.update to create golden files")
+	require.Equal(t, string(want), got)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colortest_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+)
+
+// fakeT records failures rather than failing the test.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(string, ...any) {
+	t.failed = true
+}
+
+func (t *fakeT) FailNow() {
+	t.failed = true
+	runtime.Goexit()
+}
+
+// fails reports whether fn fails the test it is given.
+func fails(t *testing.T, fn func(t testing.TB)) bool {
+	ft := &fakeT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ft)
+	}()
+	<-done
+	return ft.failed
+}
+
+func TestForceColor(t *testing.T) {
+	var (
+		prev    = color.Enabled()
+		buf     bytes.Buffer
+		prevFor = color.EnabledFor(&buf)
+	)
+
+	t.Run("enabled", func(t *testing.T) {
+		colortest.ForceColor(t, true)
+		require.True(t, color.Enabled())
+		require.Equal(t, color.FgRed.String()+"x"+color.Reset.String(), color.FgRed.Wrap("x"))
+	})
+	require.Equal(t, prev, color.Enabled())
+
+	t.Run("disabled", func(t *testing.T) {
+		colortest.ForceColor(t, false)
+		require.False(t, color.Enabled())
+		require.Equal(t, "x", color.FgRed.Wrap("x"))
+	})
+	require.Equal(t, prev, color.Enabled())
+	require.Equal(t, prevFor, color.EnabledFor(&buf))
+}
+
+func TestRequireRender(t *testing.T) {
+	str := color.FgRed.String() + "x" + color.Reset.String()
+
+	require.False(t, fails(t, func(t testing.TB) {
+		colortest.RequireRender(t, "<fg:red>x</>", str)
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireRender(t, "<fg:blue>x</>", str)
+	}))
+}
+
+func TestRequireStyleAt(t *testing.T) {
+	str := "ok " + color.FgGreen.With(color.Bold).String() + "pass" + color.Reset.String()
+
+	require.False(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyleAt(t, str, 0, color.Nop)
+		colortest.RequireStyleAt(t, str, 3, color.Bold.With(color.FgGreen))
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyleAt(t, str, 3, color.FgGreen)
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyleAt(t, str, 7, color.Nop)
+	}))
+}
+
+func TestRequireStyled(t *testing.T) {
+	colortest.ForceColor(t, true)
+	str := "a " + color.FgRed.Wrap("red") + " b " + color.FgBlue.Wrap("blue")
+
+	require.False(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyled(t, str, "red", color.FgRed)
+		colortest.RequireStyled(t, str, "blue", color.FgBlue)
+		colortest.RequireStyled(t, str, " b ", color.Nop)
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyled(t, str, "d b", color.FgRed)
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireStyled(t, str, "green", color.FgGreen)
+	}))
+}
+
+func TestRequireGolden(t *testing.T) {
+	str := color.FgYellow.String() + "warning:" + color.Reset.String() + " something\n" +
+		color.Bold.String() + "done" + color.Reset.String() + "\n"
+
+	colortest.RequireGolden(t, "golden", str)
+
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireGolden(t, "golden", "other")
+	}))
+	require.True(t, fails(t, func(t testing.TB) {
+		colortest.RequireGolden(t, "missing", str)
+	}))
+}
+
+func TestRequireGolden_Update(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(wd))
+	})
+
+	require.NoError(t, flag.Set("colortest.update", "true"))
+	t.Cleanup(func() {
+		require.NoError(t, flag.Set("colortest.update", "false"))
+	})
+
+	colortest.RequireGolden(t, "new", color.FgRed.String()+"x")
+
+	got, err := os.ReadFile(filepath.Join("testdata", "new.golden"))
+	require.NoError(t, err)
+	require.Equal(t, "<fg:red>x", string(got))
+}
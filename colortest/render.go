@@ -0,0 +1,269 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package colortest provides helpers for testing styled output without
+// comparing against raw escape sequences.
+package colortest
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.mway.dev/color"
+)
+
+// _attrs are the names of the SGR attributes 1 through 9, e.g. [color.Bold].
+var _attrs = [...]string{
+	"bold",
+	"faint",
+	"italic",
+	"underline",
+	"blink",
+	"rapid-blink",
+	"reverse",
+	"concealed",
+	"strike",
+}
+
+// _colorNames maps SGR color codes to their names, as accepted by
+// [color.ParseFgColor] and [color.ParseBgColor].
+var _colorNames = func() map[int]string {
+	names := map[int]string{}
+	for _, name := range []string{
+		"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	} {
+		for _, name := range []string{name, "hi-" + name} {
+			fg, _ := color.ParseFgColor(name)
+			bg, _ := color.ParseBgColor(name)
+			names[int(fg)] = name
+			names[int(bg)] = name
+		}
+	}
+	return names
+}()
+
+// Render returns str with its escape sequences replaced by readable tokens.
+// Each run of SGR sequences is replaced by the complete style that is active
+// after it, e.g. "<bold,fg:red>", or "</>" if no style is active, so that
+// the result does not depend on how a style was encoded. Other sequences are
+// replaced by their quoted form, e.g. "<\x1b[2K>".
+func Render(str string) string {
+	var (
+		buf   strings.Builder
+		state style
+	)
+
+	for len(str) > 0 {
+		i := strings.IndexByte(str, '\x1b')
+		if i < 0 {
+			buf.WriteString(str)
+			break
+		}
+		buf.WriteString(str[:i])
+		str = str[i:]
+
+		var sgr bool
+		for len(str) > 0 && str[0] == '\x1b' {
+			n, _ := color.EscapeLen(str)
+			seq := str[:n]
+			if !color.IsSGR(seq) {
+				break
+			}
+			state.apply(seq[2 : len(seq)-1])
+			str = str[n:]
+			sgr = true
+		}
+
+		switch {
+		case sgr && state.empty():
+			buf.WriteString("</>")
+		case sgr:
+			buf.WriteString("<" + state.String() + ">")
+		default:
+			n, _ := color.EscapeLen(str)
+			buf.WriteString("<" + strings.Trim(strconv.Quote(str[:n]), `"`) + ">")
+			str = str[n:]
+		}
+	}
+
+	return buf.String()
+}
+
+// StyleAt returns the style that is active at the i-th rune of str's
+// visible text, in the form used by [Render] without angle brackets, e.g.
+// "bold,fg:red", or an empty string if no style is active. It returns false
+// if str has fewer than i+1 visible runes.
+func StyleAt(str string, i int) (string, bool) {
+	styles := styles(str)
+	if i < 0 || i >= len(styles) {
+		return "", false
+	}
+	return styles[i], true
+}
+
+// StyleOf returns s in the form used by [StyleAt], e.g. "bold,fg:red".
+func StyleOf(s color.Style) string {
+	var state style
+	state.applyAll(s.String())
+	return state.String()
+}
+
+// styles returns the style that is active at each visible rune of str.
+func styles(str string) []string {
+	var (
+		out   []string
+		state style
+		cur   string
+	)
+
+	for len(str) > 0 {
+		if str[0] == '\x1b' {
+			n, _ := color.EscapeLen(str)
+			if seq := str[:n]; color.IsSGR(seq) {
+				state.apply(seq[2 : len(seq)-1])
+				cur = state.String()
+			}
+			str = str[n:]
+			continue
+		}
+
+		_, n := utf8.DecodeRuneInString(str)
+		out = append(out, cur)
+		str = str[n:]
+	}
+
+	return out
+}
+
+// style is the set of SGR attributes and colors that are active at a point
+// in a string.
+type style struct {
+	attrs   [len(_attrs)]bool
+	fg      string
+	bg      string
+	unknown []string
+}
+
+func (s *style) empty() bool {
+	return s.String() == ""
+}
+
+// applyAll applies each SGR sequence in str to s.
+func (s *style) applyAll(str string) {
+	for len(str) > 0 {
+		i := strings.IndexByte(str, '\x1b')
+		if i < 0 {
+			return
+		}
+		str = str[i:]
+
+		n, _ := color.EscapeLen(str)
+		if seq := str[:n]; color.IsSGR(seq) {
+			s.apply(seq[2 : len(seq)-1])
+		}
+		str = str[n:]
+	}
+}
+
+// apply applies the parameters of an SGR sequence to s.
+func (s *style) apply(params string) { //nolint:gocyclo
+	codes := strings.FieldsFunc(params, func(r rune) bool {
+		return r == ';' || r == ':'
+	})
+	if len(codes) == 0 {
+		*s = style{}
+		return
+	}
+
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			s.unknown = append(s.unknown, "sgr:"+codes[i])
+			continue
+		}
+
+		switch {
+		case code == 0:
+			*s = style{}
+		case code >= 1 && code <= len(_attrs):
+			s.attrs[code-1] = true
+		case code == 22:
+			s.attrs[0], s.attrs[1] = false, false
+		case code == 25:
+			s.attrs[4], s.attrs[5] = false, false
+		case code == 23, code == 24, code >= 27 && code <= 29:
+			s.attrs[code-21] = false
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			s.fg = _colorNames[code]
+		case code >= 40 && code <= 47, code >= 100 && code <= 107:
+			s.bg = _colorNames[code]
+		case code == 39:
+			s.fg = ""
+		case code == 49:
+			s.bg = ""
+		case code == 38, code == 48:
+			value, n := extended(codes[i+1:])
+			i += n
+			if code == 38 {
+				s.fg = value
+			} else {
+				s.bg = value
+			}
+		default:
+			s.unknown = append(s.unknown, "sgr:"+codes[i])
+		}
+	}
+}
+
+// String returns s as a comma-separated list of attributes and colors.
+func (s style) String() string {
+	var parts []string
+	for i, set := range s.attrs {
+		if set {
+			parts = append(parts, _attrs[i])
+		}
+	}
+	if len(s.fg) > 0 {
+		parts = append(parts, "fg:"+s.fg)
+	}
+	if len(s.bg) > 0 {
+		parts = append(parts, "bg:"+s.bg)
+	}
+	parts = append(parts, s.unknown...)
+	return strings.Join(parts, ",")
+}
+
+// extended parses the parameters of an extended color (SGR 38 or 48), such
+// as "5;208" or "2;255;0;0", returning the color's name (e.g. "208" or
+// "#ff0000") and the number of parameters consumed.
+func extended(codes []string) (string, int) {
+	c, n, ok := color.ParseExtendedColor(codes)
+	switch {
+	case !ok && n == 0:
+		return "?", len(codes)
+	case !ok:
+		return "?", n
+	case c.Index >= 0:
+		return strconv.Itoa(c.Index), n
+	default:
+		return c.RGB.String(), n
+	}
+}
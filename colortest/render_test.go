@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package colortest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+)
+
+func TestRender(t *testing.T) {
+	cases := map[string]struct {
+		give string
+		want string
+	}{
+		"plain": {
+			give: "hello",
+			want: "hello",
+		},
+		"color": {
+			give: color.FgRed.String() + "text" + color.Reset.String(),
+			want: "<fg:red>text</>",
+		},
+		"combined": {
+			give: color.FgRed.With(color.Bold).String() + "text" + color.Reset.String(),
+			want: "<bold,fg:red>text</>",
+		},
+		"combined order": {
+			give: "\x1b[1m\x1b[31mtext\x1b[m",
+			want: "<bold,fg:red>text</>",
+		},
+		"nested": {
+			give: "\x1b[1ma\x1b[4;92mb\x1b[24;39mc\x1b[22md",
+			want: "<bold>a<bold,underline,fg:hi-green>b<bold>c</>d",
+		},
+		"attributes": {
+			give: "\x1b[1;2;3;4;5;6;7;8;9mx\x1b[22;23;25;27;28;29mx",
+			want: "<bold,faint,italic,underline,blink,rapid-blink,reverse,concealed,strike>" +
+				"x<underline>x",
+		},
+		"background": {
+			give: color.BgHiBlue.String() + "x" + "\x1b[49m",
+			want: "<bg:hi-blue>x</>",
+		},
+		"256": {
+			give: color.Fg256(208).String() + color.Bg256(17).String() + "x",
+			want: "<fg:208,bg:17>x",
+		},
+		"rgb": {
+			give: color.RGB{R: 255, G: 128}.Fg().String() + "x",
+			want: "<fg:#ff8000>x",
+		},
+		"unknown": {
+			give: "\x1b[53mx",
+			want: "<sgr:53>x",
+		},
+		"other": {
+			give: "a\x1b[2Kb\x1b]0;title\a",
+			want: `a<\x1b[2K>b<\x1b]0;title\a>`,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, colortest.Render(tt.give))
+		})
+	}
+}
+
+func TestStyleAt(t *testing.T) {
+	str := "a" + color.FgRed.String() + "é" + color.Reset.String() + "c"
+
+	got, ok := colortest.StyleAt(str, 0)
+	require.True(t, ok)
+	require.Equal(t, "", got)
+
+	got, ok = colortest.StyleAt(str, 1)
+	require.True(t, ok)
+	require.Equal(t, "fg:red", got)
+
+	got, ok = colortest.StyleAt(str, 2)
+	require.True(t, ok)
+	require.Equal(t, "", got)
+
+	_, ok = colortest.StyleAt(str, 3)
+	require.False(t, ok)
+	_, ok = colortest.StyleAt(str, -1)
+	require.False(t, ok)
+}
+
+func TestStyleOf(t *testing.T) {
+	require.Equal(t, "", colortest.StyleOf(color.Nop))
+	require.Equal(t, "bold,fg:cyan", colortest.StyleOf(color.FgCyan.With(color.Bold)))
+	require.Equal(t, "bg:#000000", colortest.StyleOf(color.RGB{}.Bg()))
+}
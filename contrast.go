@@ -0,0 +1,194 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+
+	"go.mway.dev/errors"
+)
+
+// ErrInsufficientContrast is returned when a style's colors do not meet a
+// contrast requirement.
+var ErrInsufficientContrast = errors.New("insufficient contrast")
+
+// A WCAGLevel is a WCAG 2 conformance level for the contrast between text and
+// its background.
+type WCAGLevel int
+
+// WCAG conformance levels.
+const (
+	// WCAGAA requires a contrast ratio of at least 4.5:1.
+	WCAGAA WCAGLevel = iota
+	// WCAGAALarge requires a contrast ratio of at least 3:1, and applies to
+	// large or bold text.
+	WCAGAALarge
+	// WCAGAAA requires a contrast ratio of at least 7:1.
+	WCAGAAA
+	// WCAGAAALarge requires a contrast ratio of at least 4.5:1, and applies
+	// to large or bold text.
+	WCAGAAALarge
+)
+
+// Ratio returns the minimum contrast ratio required by l.
+func (l WCAGLevel) Ratio() float64 {
+	switch l {
+	case WCAGAALarge:
+		return 3
+	case WCAGAAA:
+		return 7
+	default:
+		return 4.5
+	}
+}
+
+// String returns a human-readable name for l.
+func (l WCAGLevel) String() string {
+	switch l {
+	case WCAGAA:
+		return "AA"
+	case WCAGAALarge:
+		return "AA (large text)"
+	case WCAGAAA:
+		return "AAA"
+	case WCAGAAALarge:
+		return "AAA (large text)"
+	default:
+		return "unknown"
+	}
+}
+
+// Contrast returns the WCAG 2 contrast ratio between a and b, from 1 (no
+// contrast) to 21 (black on white).
+func Contrast(a RGB, b RGB) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	return (max(la, lb) + 0.05) / (min(la, lb) + 0.05)
+}
+
+// StyleContrast returns the contrast ratio between style's foreground and
+// background colors, as resolved by p. The terminal's default foreground and
+// background, fg and bg, are used for colors that style does not set.
+func (p Palette) StyleContrast(style Style, fg RGB, bg RGB) float64 {
+	if x, ok := p.Foreground(style); ok {
+		fg = x
+	}
+	if x, ok := p.Background(style); ok {
+		bg = x
+	}
+	return Contrast(fg, bg)
+}
+
+// CheckContrast returns an error wrapping [ErrInsufficientContrast] if the
+// contrast between style's colors (see [Palette.StyleContrast]) does not meet
+// level.
+func (p Palette) CheckContrast(style Style, fg RGB, bg RGB, level WCAGLevel) error {
+	if ratio := p.StyleContrast(style, fg, bg); ratio < level.Ratio() {
+		return errors.Wrap(ErrInsufficientContrast, fmt.Sprintf(
+			"%.2f:1 is below %.1f:1 required by WCAG %s",
+			ratio,
+			level.Ratio(),
+			level,
+		))
+	}
+	return nil
+}
+
+// AdjustStyle returns style with its foreground replaced, if needed, so that
+// its contrast against its background is at least ratio (see
+// [AdjustContrast]). The terminal's default foreground and background, fg and
+// bg, are used for colors that style does not set.
+func (p Palette) AdjustStyle(style Style, fg RGB, bg RGB, ratio float64) Style {
+	if x, ok := p.Foreground(style); ok {
+		fg = x
+	}
+	if x, ok := p.Background(style); ok {
+		bg = x
+	}
+
+	adjusted := AdjustContrast(fg, bg, ratio)
+	if adjusted == fg {
+		return style
+	}
+	if style == nil {
+		return adjusted.Fg()
+	}
+	return style.With(adjusted.Fg())
+}
+
+// AdjustContrast returns fg with its lightness changed as little as possible,
+// preserving its hue, so that its contrast against bg is at least ratio. If
+// no lightness meets ratio, it returns whichever of black and white contrasts
+// most with bg.
+func AdjustContrast(fg RGB, bg RGB, ratio float64) RGB {
+	if Contrast(fg, bg) >= ratio {
+		return fg
+	}
+
+	var (
+		lab              = fg.OKLab()
+		bgL              = bg.OKLab().L
+		lighter, okLight = searchLightness(lab, max(lab.L, bgL), 1, bg, ratio)
+		darker, okDark   = searchLightness(lab, min(lab.L, bgL), 0, bg, ratio)
+	)
+
+	switch {
+	case okLight && okDark:
+		if lighter.OKLab().L-lab.L <= lab.L-darker.OKLab().L {
+			return lighter
+		}
+		return darker
+	case okLight:
+		return lighter
+	case okDark:
+		return darker
+	default:
+	}
+
+	white := RGB{R: 255, G: 255, B: 255}
+	if Contrast(white, bg) >= Contrast(RGB{}, bg) {
+		return white
+	}
+	return RGB{}
+}
+
+// searchLightness returns the color with lab's hue whose lightness is closest
+// to from, in the direction of to, that contrasts with bg by at least ratio.
+// Contrast must increase monotonically from from to to.
+func searchLightness(lab OKLab, from float64, to float64, bg RGB, ratio float64) (RGB, bool) {
+	at := func(l float64) RGB {
+		return OKLab{L: l, A: lab.A, B: lab.B}.RGB()
+	}
+
+	if Contrast(at(to), bg) < ratio {
+		return RGB{}, false
+	}
+
+	for i := 0; i < 32; i++ {
+		mid := (from + to) / 2
+		if Contrast(at(mid), bg) >= ratio {
+			to = mid
+		} else {
+			from = mid
+		}
+	}
+
+	return at(to), true
+}
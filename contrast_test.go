@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_black = RGB{}
+	_white = RGB{R: 255, G: 255, B: 255}
+)
+
+func TestContrast(t *testing.T) {
+	require.InDelta(t, 21, Contrast(_black, _white), 1e-9)
+	require.InDelta(t, 21, Contrast(_white, _black), 1e-9)
+	require.InDelta(t, 1, Contrast(_white, _white), 1e-9)
+	require.InDelta(t, 4.54, Contrast(RGB{R: 118, G: 118, B: 118}, _white), 0.01)
+	require.InDelta(t, 3.99, Contrast(RGB{R: 255}, _white), 0.01)
+}
+
+func TestWCAGLevel(t *testing.T) {
+	cases := map[WCAGLevel]struct {
+		ratio float64
+		name  string
+	}{
+		WCAGAA:       {ratio: 4.5, name: "AA"},
+		WCAGAALarge:  {ratio: 3, name: "AA (large text)"},
+		WCAGAAA:      {ratio: 7, name: "AAA"},
+		WCAGAAALarge: {ratio: 4.5, name: "AAA (large text)"},
+	}
+
+	for level, tt := range cases {
+		require.InDelta(t, tt.ratio, level.Ratio(), 0)
+		require.Equal(t, tt.name, level.String())
+	}
+	require.Equal(t, "unknown", WCAGLevel(-1).String())
+}
+
+func TestPalette_StyleContrast(t *testing.T) {
+	p := DefaultPalette()
+
+	// Unset colors fall back to the given defaults.
+	require.InDelta(t, 21, p.StyleContrast(Nop, _white, _black), 1e-9)
+	require.InDelta(t, 21, p.StyleContrast(nil, _black, _white), 1e-9)
+	require.InDelta(t, Contrast(p[1], _black), p.StyleContrast(FgRed, _white, _black), 1e-9)
+	require.InDelta(t, Contrast(_white, p[4]), p.StyleContrast(BgBlue, _white, _black), 1e-9)
+	require.InDelta(
+		t,
+		Contrast(p[11], p[4]),
+		p.StyleContrast(Combine(Bold, FgHiYellow, BgBlue), _black, _white),
+		1e-9,
+	)
+	require.InDelta(
+		t,
+		Contrast(RGB{R: 10}, p.Indexed(250)),
+		p.StyleContrast(Combine(RGB{R: 10}.Fg(), Bg256(250)), _white, _black),
+		1e-9,
+	)
+}
+
+func TestPalette_CheckContrast(t *testing.T) {
+	p := DefaultPalette()
+
+	require.NoError(t, p.CheckContrast(Combine(FgWhite, BgBlack), _white, _black, WCAGAAA))
+	require.NoError(t, p.CheckContrast(FgYellow, _white, _black, WCAGAA))
+
+	err := p.CheckContrast(FgBlue, _white, _black, WCAGAA)
+	require.ErrorIs(t, err, ErrInsufficientContrast)
+	require.Contains(t, err.Error(), "2.23:1 is below 4.5:1 required by WCAG AA")
+
+	err = p.CheckContrast(Combine(FgWhite, BgHiYellow), _white, _black, WCAGAALarge)
+	require.ErrorIs(t, err, ErrInsufficientContrast)
+}
+
+func TestAdjustContrast(t *testing.T) {
+	cases := map[string]struct {
+		fg    RGB
+		bg    RGB
+		ratio float64
+	}{
+		"blue on black":   {fg: RGB{B: 238}, bg: _black, ratio: 4.5},
+		"yellow on white": {fg: RGB{R: 205, G: 205}, bg: _white, ratio: 4.5},
+		"gray on gray": {
+			fg:    RGB{R: 120, G: 120, B: 120},
+			bg:    RGB{R: 128, G: 128, B: 128},
+			ratio: 3,
+		},
+		"red on dark red":    {fg: RGB{R: 200}, bg: RGB{R: 60}, ratio: 7},
+		"green on white AAA": {fg: RGB{G: 205}, bg: _white, ratio: 7},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				adjusted = AdjustContrast(tt.fg, tt.bg, tt.ratio)
+				before   = tt.fg.OKLab()
+				after    = adjusted.OKLab()
+			)
+
+			require.GreaterOrEqual(t, Contrast(adjusted, tt.bg), tt.ratio)
+			require.NotEqual(t, tt.fg, adjusted)
+			if after.Chroma() > 0.02 && before.Chroma() > 0.02 {
+				require.InDelta(t, before.Hue(), after.Hue(), 10)
+			}
+
+			// The adjustment is minimal: a slightly smaller change does not
+			// meet the ratio.
+			l := before.L + (after.L-before.L)*0.95
+			require.Less(t, Contrast(OKLab{L: l, A: before.A, B: before.B}.RGB(), tt.bg), tt.ratio)
+		})
+	}
+
+	// Colors that already meet the ratio are unchanged.
+	require.Equal(t, _white, AdjustContrast(_white, _black, 21))
+
+	// Unreachable ratios fall back to black or white.
+	require.Equal(t, _white, AdjustContrast(RGB{R: 10}, RGB{R: 60, G: 60, B: 60}, 21))
+	require.Equal(t, _black, AdjustContrast(RGB{R: 250}, RGB{R: 200, G: 200, B: 200}, 21))
+}
+
+func TestPalette_AdjustStyle(t *testing.T) {
+	p := DefaultPalette()
+
+	require.Equal(t, FgWhite, p.AdjustStyle(FgWhite, _white, _black, 4.5))
+
+	style := p.AdjustStyle(Combine(Bold, FgBlue), _white, _black, 4.5)
+	require.GreaterOrEqual(t, p.StyleContrast(style, _white, _black), 4.5)
+	require.Contains(t, style.Code(), "1;34;38;2;")
+
+	style = p.AdjustStyle(nil, RGB{R: 40, G: 40, B: 40}, _black, 4.5)
+	fg, ok := p.Foreground(style)
+	require.True(t, ok)
+	require.GreaterOrEqual(t, Contrast(fg, _black), 4.5)
+}
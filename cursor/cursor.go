@@ -0,0 +1,151 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package cursor provides cursor movement and screen control sequences.
+package cursor
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+// Fixed sequences.
+const (
+	// Save saves the cursor position (DECSC).
+	Save Sequence = "\x1b7"
+	// Restore restores the cursor position saved by [Save] (DECRC).
+	Restore Sequence = "\x1b8"
+	// Hide hides the cursor (DECTCEM).
+	Hide Sequence = "\x1b[?25l"
+	// Show shows the cursor (DECTCEM).
+	Show Sequence = "\x1b[?25h"
+	// Home moves the cursor to the top left corner of the screen.
+	Home Sequence = "\x1b[H"
+	// EraseLine erases the line the cursor is on (EL 2).
+	EraseLine Sequence = "\x1b[2K"
+	// EraseLineRight erases from the cursor to the end of the line (EL 0).
+	EraseLineRight Sequence = "\x1b[K"
+	// EraseLineLeft erases from the start of the line to the cursor (EL 1).
+	EraseLineLeft Sequence = "\x1b[1K"
+	// EraseScreen erases the whole screen (ED 2).
+	EraseScreen Sequence = "\x1b[2J"
+	// EraseDown erases from the cursor to the end of the screen (ED 0).
+	EraseDown Sequence = "\x1b[J"
+	// EraseUp erases from the start of the screen to the cursor (ED 1).
+	EraseUp Sequence = "\x1b[1J"
+	// AltScreen switches to the alternate screen buffer, saving the cursor.
+	AltScreen Sequence = "\x1b[?1049h"
+	// MainScreen switches back to the main screen buffer from [AltScreen],
+	// restoring the cursor.
+	MainScreen Sequence = "\x1b[?1049l"
+	// BeginSync begins a synchronized update (DEC mode 2026), during which
+	// the terminal defers drawing so that partial updates do not flicker.
+	// Terminals that do not support synchronized updates ignore it.
+	BeginSync Sequence = "\x1b[?2026h"
+	// EndSync ends a synchronized update begun by [BeginSync].
+	EndSync Sequence = "\x1b[?2026l"
+)
+
+// A Sequence is a cursor movement or screen control sequence. Like a
+// [color.Style], its [Sequence.String] is unconditional, while
+// [Sequence.Escape] and [Fprint] only produce output if color (and thus
+// terminal control) is enabled.
+type Sequence string
+
+// Up moves the cursor up n lines (CUU).
+func Up(n int) Sequence {
+	return csi(n, 'A')
+}
+
+// Down moves the cursor down n lines (CUD).
+func Down(n int) Sequence {
+	return csi(n, 'B')
+}
+
+// Forward moves the cursor right n columns (CUF).
+func Forward(n int) Sequence {
+	return csi(n, 'C')
+}
+
+// Back moves the cursor left n columns (CUB).
+func Back(n int) Sequence {
+	return csi(n, 'D')
+}
+
+// NextLine moves the cursor to the start of the line n lines down (CNL).
+func NextLine(n int) Sequence {
+	return csi(n, 'E')
+}
+
+// PrevLine moves the cursor to the start of the line n lines up (CPL).
+func PrevLine(n int) Sequence {
+	return csi(n, 'F')
+}
+
+// Column moves the cursor to column col, counting from 1 (CHA).
+func Column(col int) Sequence {
+	return csi(max(col, 1), 'G')
+}
+
+// Position moves the cursor to row and col, counting from 1 (CUP).
+func Position(row int, col int) Sequence {
+	return Sequence("\x1b[" + strconv.Itoa(max(row, 1)) + ";" + strconv.Itoa(max(col, 1)) + "H")
+}
+
+// String returns s, regardless of whether color is enabled.
+func (s Sequence) String() string {
+	return string(s)
+}
+
+// Escape returns s if color is enabled (see [color.Enabled]), or an empty
+// string otherwise.
+func (s Sequence) Escape() string {
+	if !color.Enabled() {
+		return ""
+	}
+	return string(s)
+}
+
+// Fprint writes the given sequences to w if color is enabled for w (see
+// [color.EnabledFor]), and does nothing otherwise, so that control sequences
+// are not written to files or pipes.
+func Fprint(w io.Writer, seqs ...Sequence) (int, error) {
+	if len(seqs) == 0 || !color.EnabledFor(w) {
+		return 0, nil
+	}
+
+	var buf strings.Builder
+	for _, seq := range seqs {
+		buf.WriteString(string(seq))
+	}
+	return io.WriteString(w, buf.String())
+}
+
+// csi returns the control sequence with parameter n and the given final
+// byte, or an empty sequence if n is not positive.
+func csi(n int, final byte) Sequence {
+	if n <= 0 {
+		return ""
+	}
+	return Sequence("\x1b[" + strconv.Itoa(n) + string(final))
+}
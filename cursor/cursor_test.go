@@ -0,0 +1,98 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package cursor_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/cursor"
+)
+
+func TestSequences(t *testing.T) {
+	cases := map[string]struct {
+		seq  cursor.Sequence
+		want string
+	}{
+		"Up":           {seq: cursor.Up(3), want: "\x1b[3A"},
+		"Down":         {seq: cursor.Down(1), want: "\x1b[1B"},
+		"Forward":      {seq: cursor.Forward(10), want: "\x1b[10C"},
+		"Back":         {seq: cursor.Back(2), want: "\x1b[2D"},
+		"NextLine":     {seq: cursor.NextLine(2), want: "\x1b[2E"},
+		"PrevLine":     {seq: cursor.PrevLine(4), want: "\x1b[4F"},
+		"Column":       {seq: cursor.Column(5), want: "\x1b[5G"},
+		"Column zero":  {seq: cursor.Column(0), want: "\x1b[1G"},
+		"Position":     {seq: cursor.Position(2, 7), want: "\x1b[2;7H"},
+		"Position min": {seq: cursor.Position(-1, 0), want: "\x1b[1;1H"},
+		"Up zero":      {seq: cursor.Up(0), want: ""},
+		"Down neg":     {seq: cursor.Down(-1), want: ""},
+		"Save":         {seq: cursor.Save, want: "\x1b7"},
+		"Restore":      {seq: cursor.Restore, want: "\x1b8"},
+		"Hide":         {seq: cursor.Hide, want: "\x1b[?25l"},
+		"Show":         {seq: cursor.Show, want: "\x1b[?25h"},
+		"EraseLine":    {seq: cursor.EraseLine, want: "\x1b[2K"},
+		"EraseScreen":  {seq: cursor.EraseScreen, want: "\x1b[2J"},
+		"AltScreen":    {seq: cursor.AltScreen, want: "\x1b[?1049h"},
+		"MainScreen":   {seq: cursor.MainScreen, want: "\x1b[?1049l"},
+		"BeginSync":    {seq: cursor.BeginSync, want: "\x1b[?2026h"},
+		"EndSync":      {seq: cursor.EndSync, want: "\x1b[?2026l"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.seq.String())
+			require.Zero(t, color.VisibleWidth(tt.seq.String()))
+		})
+	}
+}
+
+func TestSequence_Escape(t *testing.T) {
+	colortest.ForceColor(t, true)
+	require.Equal(t, "\x1b[2A", cursor.Up(2).Escape())
+
+	colortest.ForceColor(t, false)
+	require.Equal(t, "", cursor.Up(2).Escape())
+	require.Equal(t, "\x1b[2A", cursor.Up(2).String())
+}
+
+func TestFprint(t *testing.T) {
+	var buf bytes.Buffer
+
+	colortest.ForceColor(t, true)
+	n, err := cursor.Fprint(&buf, cursor.Up(1), cursor.EraseLine, cursor.Column(1))
+	require.NoError(t, err)
+	require.Equal(t, "\x1b[1A\x1b[2K\x1b[1G", buf.String())
+	require.Equal(t, buf.Len(), n)
+
+	buf.Reset()
+	n, err = cursor.Fprint(&buf)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	colortest.ForceColor(t, false)
+	n, err = cursor.Fprint(&buf, cursor.Hide)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Empty(t, buf.String())
+}
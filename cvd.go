@@ -0,0 +1,198 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"strings"
+
+	"go.mway.dev/errors"
+)
+
+// _cvdDistance is the minimum OKLab distance at which two colors are
+// considered clearly distinguishable as text.
+const _cvdDistance = 0.2
+
+// ErrInvalidDeficiency is returned when attempting to parse a color vision
+// deficiency using an unknown name.
+var ErrInvalidDeficiency = errors.New("invalid color vision deficiency")
+
+// A Deficiency is a color vision deficiency.
+type Deficiency int
+
+// Color vision deficiencies.
+const (
+	// NoDeficiency is normal color vision.
+	NoDeficiency Deficiency = iota
+	// Protanopia is the absence of red cones, which makes reds and greens
+	// hard to tell apart and reds appear dark.
+	Protanopia
+	// Deuteranopia is the absence of green cones, which makes reds and
+	// greens hard to tell apart.
+	Deuteranopia
+	// Tritanopia is the absence of blue cones, which makes blues and greens,
+	// and yellows and pinks, hard to tell apart.
+	Tritanopia
+)
+
+// _cvdMatrices are the linear RGB transforms that simulate each deficiency,
+// from Machado, Oliveira, and Fernandes (2009) at full severity.
+var _cvdMatrices = map[Deficiency][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// Deficiencies returns the color vision deficiencies that can be simulated.
+func Deficiencies() []Deficiency {
+	return []Deficiency{Protanopia, Deuteranopia, Tritanopia}
+}
+
+// ParseDeficiency parses the given name, such as "deuteranopia" or "deutan",
+// into a [Deficiency]. Names are case-insensitive, and "none" or an empty name
+// is [NoDeficiency].
+func ParseDeficiency(name string) (Deficiency, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return NoDeficiency, nil
+	case "protanopia", "protan":
+		return Protanopia, nil
+	case "deuteranopia", "deutan":
+		return Deuteranopia, nil
+	case "tritanopia", "tritan":
+		return Tritanopia, nil
+	default:
+		return NoDeficiency, errors.Wrap(ErrInvalidDeficiency, name)
+	}
+}
+
+// String returns a human-readable name for d.
+func (d Deficiency) String() string {
+	switch d {
+	case NoDeficiency:
+		return "none"
+	case Protanopia:
+		return "protanopia"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Tritanopia:
+		return "tritanopia"
+	default:
+		return "unknown"
+	}
+}
+
+// Simulate returns c as it appears to someone with d.
+func (d Deficiency) Simulate(c RGB) RGB {
+	m, ok := _cvdMatrices[d]
+	if !ok {
+		return c
+	}
+
+	r, g, b := c.linear()
+	return RGB{
+		R: fromLinear(m[0][0]*r + m[0][1]*g + m[0][2]*b),
+		G: fromLinear(m[1][0]*r + m[1][1]*g + m[1][2]*b),
+		B: fromLinear(m[2][0]*r + m[2][1]*g + m[2][2]*b),
+	}
+}
+
+// SimulatePalette returns p as it appears to someone with d.
+func (d Deficiency) SimulatePalette(p Palette) Palette {
+	for i := range p {
+		p[i] = d.Simulate(p[i])
+	}
+	return p
+}
+
+// A CVDConflict is a pair of styles whose foreground colors are
+// distinguishable with normal color vision, but not with a deficiency.
+type CVDConflict struct {
+	// A is the first style of the pair.
+	A Style
+	// B is the second style of the pair.
+	B Style
+	// Deficiency is the deficiency with which the styles are
+	// indistinguishable.
+	Deficiency Deficiency
+	// Distance is the perceived difference, in OKLab, between the styles'
+	// colors as they appear with the deficiency.
+	Distance float64
+}
+
+// CVDConflicts returns the pairs of styles whose foreground colors, as
+// resolved by p, become indistinguishable with any simulated [Deficiency].
+// Styles that do not set a foreground color are ignored.
+func (p Palette) CVDConflicts(styles ...Style) []CVDConflict {
+	var conflicts []CVDConflict
+	for _, d := range Deficiencies() {
+		conflicts = append(conflicts, d.Conflicts(p, styles...)...)
+	}
+	return conflicts
+}
+
+// Conflicts returns the pairs of styles whose foreground colors, as resolved
+// by p, are distinguishable with normal color vision but not with d. Styles
+// that do not set a foreground color are ignored.
+func (d Deficiency) Conflicts(p Palette, styles ...Style) []CVDConflict {
+	var (
+		conflicts []CVDConflict
+		colors    = make([]*RGB, len(styles))
+	)
+
+	for i, style := range styles {
+		if fg, ok := p.Foreground(style); ok {
+			colors[i] = &fg
+		}
+	}
+
+	for i, a := range colors {
+		for j := i + 1; j < len(colors) && a != nil; j++ {
+			b := colors[j]
+			if b == nil || a.OKLab().Distance(b.OKLab()) < _cvdDistance {
+				continue
+			}
+
+			dist := d.Simulate(*a).OKLab().Distance(d.Simulate(*b).OKLab())
+			if dist < _cvdDistance {
+				conflicts = append(conflicts, CVDConflict{
+					A:          styles[i],
+					B:          styles[j],
+					Deficiency: d,
+					Distance:   dist,
+				})
+			}
+		}
+	}
+
+	return conflicts
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeficiency(t *testing.T) {
+	cases := map[string]Deficiency{
+		"":             NoDeficiency,
+		"none":         NoDeficiency,
+		"protanopia":   Protanopia,
+		"Protan":       Protanopia,
+		"deuteranopia": Deuteranopia,
+		" deutan ":     Deuteranopia,
+		"TRITANOPIA":   Tritanopia,
+		"tritan":       Tritanopia,
+	}
+
+	for name, want := range cases {
+		d, err := ParseDeficiency(name)
+		require.NoError(t, err, name)
+		require.Equal(t, want, d, name)
+	}
+
+	_, err := ParseDeficiency("achromat")
+	require.ErrorIs(t, err, ErrInvalidDeficiency)
+}
+
+func TestDeficiency_String(t *testing.T) {
+	require.Equal(t, "none", NoDeficiency.String())
+	for _, d := range Deficiencies() {
+		parsed, err := ParseDeficiency(d.String())
+		require.NoError(t, err)
+		require.Equal(t, d, parsed)
+	}
+	require.Equal(t, "unknown", Deficiency(-1).String())
+}
+
+func TestDeficiency_Simulate(t *testing.T) {
+	var (
+		red   = RGB{R: 205}
+		green = RGB{G: 205}
+		blue  = RGB{B: 238}
+	)
+
+	// Grays are unaffected.
+	for _, d := range append(Deficiencies(), NoDeficiency) {
+		require.Equal(t, _white, d.Simulate(_white), d.String())
+		require.Equal(t, _black, d.Simulate(_black), d.String())
+	}
+
+	require.Equal(t, red, NoDeficiency.Simulate(red))
+	require.Equal(t, red, Deficiency(-1).Simulate(red))
+
+	// Reds and greens of similar lightness collapse without red or green
+	// cones, but remain distinct without blue cones.
+	var (
+		brick = RGB{R: 200, G: 80, B: 60}
+		olive = RGB{R: 110, G: 130, B: 50}
+	)
+	for _, d := range []Deficiency{Protanopia, Deuteranopia} {
+		before := brick.OKLab().Distance(olive.OKLab())
+		after := d.Simulate(brick).OKLab().Distance(d.Simulate(olive).OKLab())
+		require.Less(t, after, before/2, d.String())
+	}
+	require.Greater(
+		t,
+		Tritanopia.Simulate(brick).OKLab().Distance(Tritanopia.Simulate(olive).OKLab()),
+		0.2,
+	)
+	require.Less(
+		t,
+		Deuteranopia.Simulate(red).OKLab().Distance(Deuteranopia.Simulate(green).OKLab()),
+		red.OKLab().Distance(green.OKLab())/2,
+	)
+
+	// Blue loses most of its blueness without blue cones.
+	require.Less(t, math.Abs(Tritanopia.Simulate(blue).OKLab().B), math.Abs(blue.OKLab().B)/2)
+}
+
+func TestDeficiency_SimulatePalette(t *testing.T) {
+	var (
+		p         = DefaultPalette()
+		simulated = Deuteranopia.SimulatePalette(p)
+	)
+
+	require.Equal(t, DefaultPalette(), p)
+	for i := range p {
+		require.Equal(t, Deuteranopia.Simulate(p[i]), simulated[i])
+	}
+}
+
+func TestPalette_CVDConflicts(t *testing.T) {
+	p := DefaultPalette()
+
+	conflicts := p.CVDConflicts(FgRed, FgGreen, Bold)
+	require.NotEmpty(t, conflicts)
+
+	var found []Deficiency
+	for _, conflict := range conflicts {
+		require.Equal(t, FgRed, conflict.A)
+		require.Equal(t, FgGreen, conflict.B)
+		require.Less(t, conflict.Distance, _cvdDistance)
+		found = append(found, conflict.Deficiency)
+	}
+	require.Equal(t, []Deficiency{Deuteranopia}, found)
+
+	// Pairs that are already indistinguishable are not reported.
+	require.Empty(t, p.CVDConflicts(FgRed, FgRed, Fg256(160), RGB{R: 220}.Fg()))
+	require.Empty(t, p.CVDConflicts(FgBlue, FgHiWhite))
+	require.Empty(t, p.CVDConflicts())
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// _debugNames are the names of the [Color] constants, by code.
+	_debugNames = map[Color]string{
+		Reset:        "Reset",
+		Bold:         "Bold",
+		Faint:        "Faint",
+		Italic:       "Italic",
+		Underline:    "Underline",
+		BlinkSlow:    "BlinkSlow",
+		BlinkRapid:   "BlinkRapid",
+		ReverseVideo: "ReverseVideo",
+		Concealed:    "Concealed",
+		CrossedOut:   "CrossedOut",
+		FgBlack:      "FgBlack",
+		FgRed:        "FgRed",
+		FgGreen:      "FgGreen",
+		FgYellow:     "FgYellow",
+		FgBlue:       "FgBlue",
+		FgMagenta:    "FgMagenta",
+		FgCyan:       "FgCyan",
+		FgWhite:      "FgWhite",
+		FgHiBlack:    "FgHiBlack",
+		FgHiRed:      "FgHiRed",
+		FgHiGreen:    "FgHiGreen",
+		FgHiYellow:   "FgHiYellow",
+		FgHiBlue:     "FgHiBlue",
+		FgHiMagenta:  "FgHiMagenta",
+		FgHiCyan:     "FgHiCyan",
+		FgHiWhite:    "FgHiWhite",
+		BgBlack:      "BgBlack",
+		BgRed:        "BgRed",
+		BgGreen:      "BgGreen",
+		BgYellow:     "BgYellow",
+		BgBlue:       "BgBlue",
+		BgMagenta:    "BgMagenta",
+		BgCyan:       "BgCyan",
+		BgWhite:      "BgWhite",
+		BgHiBlack:    "BgHiBlack",
+		BgHiRed:      "BgHiRed",
+		BgHiGreen:    "BgHiGreen",
+		BgHiYellow:   "BgHiYellow",
+		BgHiBlue:     "BgHiBlue",
+		BgHiMagenta:  "BgHiMagenta",
+		BgHiCyan:     "BgHiCyan",
+		BgHiWhite:    "BgHiWhite",
+	}
+
+	// _csiNames are the mnemonics of common CSI sequences, by final byte.
+	_csiNames = map[byte]string{
+		'A': "CUU",
+		'B': "CUD",
+		'C': "CUF",
+		'D': "CUB",
+		'E': "CNL",
+		'F': "CPL",
+		'G': "CHA",
+		'H': "CUP",
+		'J': "ED",
+		'K': "EL",
+		'S': "SU",
+		'T': "SD",
+		'f': "HVP",
+	}
+
+	_ io.Writer = (*DebugWriter)(nil)
+)
+
+// Debug returns str with each escape sequence replaced by a readable token
+// describing it, e.g. "[FgRed]", "[Bold]", "[Reset]", "[CUU 2]", or
+// "[OSC 8 https://example.com]", which is useful for inspecting what was
+// actually written. SGR sequences are named after the corresponding [Color]
+// constants, with one token per attribute; extended colors are written as
+// e.g. "[Fg256 208]" or "[FgRGB #ff8000]".
+func Debug(str string) string {
+	out, rest := debug(str)
+	if len(rest) > 0 {
+		out += debugToken(rest)
+	}
+	return out
+}
+
+// A DebugWriter is an [io.Writer] that writes its input to another writer
+// with escape sequences replaced as [Debug] does, e.g. to inspect the output
+// of [Copy]. Escape sequences that are split across writes are buffered until
+// they are complete or the writer is flushed. It is safe for concurrent use.
+type DebugWriter struct {
+	w       io.Writer
+	mu      sync.Mutex
+	pending string
+}
+
+// NewDebugWriter creates a new [DebugWriter] that writes to w.
+func NewDebugWriter(w io.Writer) *DebugWriter {
+	return &DebugWriter{
+		w: w,
+	}
+}
+
+// Write writes p to d's underlying writer, with its escape sequences
+// replaced.
+func (d *DebugWriter) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out, rest := debug(d.pending + string(p))
+	d.pending = rest
+	if _, err := io.WriteString(d.w, out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes any buffered incomplete escape sequence to d's underlying
+// writer.
+func (d *DebugWriter) Flush() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.pending) == 0 {
+		return nil
+	}
+
+	_, err := io.WriteString(d.w, debugToken(d.pending))
+	d.pending = ""
+	return err
+}
+
+// debug replaces the escape sequences in str, returning the result and any
+// incomplete escape sequence at the end of str.
+func debug(str string) (string, string) {
+	var buf strings.Builder
+	for len(str) > 0 {
+		i := strings.IndexByte(str, _esc)
+		if i < 0 {
+			buf.WriteString(str)
+			break
+		}
+
+		buf.WriteString(str[:i])
+		str = str[i:]
+
+		n, complete := EscapeLen(str)
+		if !complete {
+			return buf.String(), str
+		}
+
+		buf.WriteString(debugToken(str[:n]))
+		str = str[n:]
+	}
+	return buf.String(), ""
+}
+
+// debugToken returns the token(s) describing the escape sequence seq.
+func debugToken(seq string) string {
+	if len(seq) < 2 {
+		return "[ESC]"
+	}
+
+	switch seq[1] {
+	case '[':
+		if IsSGR(seq) {
+			return debugSGR(seq[2 : len(seq)-1])
+		}
+		return debugCSI(seq)
+	case ']':
+		payload := strings.TrimSuffix(strings.TrimSuffix(seq[2:], "\a"), "\x1b\\")
+		fields := strings.Split(payload, ";")
+		parts := []string{"OSC", fields[0]}
+		for _, field := range fields[1:] {
+			if len(field) > 0 {
+				parts = append(parts, field)
+			}
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case 'P':
+		payload := strings.TrimSuffix(seq[2:], "\x1b\\")
+		return "[DCS " + strings.Trim(strconv.Quote(payload), `"`) + "]"
+	case '7':
+		return "[DECSC]"
+	case '8':
+		return "[DECRC]"
+	default:
+		return "[ESC " + strings.Trim(strconv.Quote(seq[1:]), `"`) + "]"
+	}
+}
+
+// debugCSI returns the token describing the non-SGR CSI sequence seq.
+func debugCSI(seq string) string {
+	var (
+		final  = seq[len(seq)-1]
+		params = seq[2 : len(seq)-1]
+		name   = _csiNames[final]
+	)
+
+	switch {
+	case strings.HasPrefix(params, "?") && final == 'h':
+		name, params = "DECSET", params[1:]
+	case strings.HasPrefix(params, "?") && final == 'l':
+		name, params = "DECRST", params[1:]
+	case len(name) == 0:
+		name, params = "CSI", params+string(final)
+	}
+
+	if len(params) == 0 {
+		return "[" + name + "]"
+	}
+	return "[" + name + " " + params + "]"
+}
+
+// debugSGR returns the tokens describing the SGR parameters params.
+func debugSGR(params string) string {
+	if len(params) == 0 {
+		return "[" + _debugNames[Reset] + "]"
+	}
+
+	var (
+		buf   strings.Builder
+		codes = strings.Split(params, ";")
+	)
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil || code < 0 || code > 255 {
+			buf.WriteString("[SGR " + codes[i] + "]")
+			continue
+		}
+
+		if name, ok := _debugNames[Color(code)]; ok {
+			buf.WriteString("[" + name + "]")
+			continue
+		}
+
+		if code == 38 || code == 48 {
+			prefix := "Fg"
+			if code == 48 {
+				prefix = "Bg"
+			}
+
+			c, n, ok := ParseExtendedColor(codes[i+1:])
+			switch {
+			case ok && c.Index >= 0:
+				buf.WriteString("[" + prefix + "256 " + strconv.Itoa(c.Index) + "]")
+			case ok:
+				buf.WriteString("[" + prefix + "RGB " + c.RGB.String() + "]")
+			default:
+				// Keep the parameters of an invalid color together.
+				buf.WriteString("[SGR " + strings.Join(codes[i:i+1+n], ";") + "]")
+			}
+			i += n
+			continue
+		}
+
+		buf.WriteString("[SGR " + codes[i] + "]")
+	}
+	return buf.String()
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebug(t *testing.T) {
+	cases := map[string]struct {
+		give string
+		want string
+	}{
+		"plain":    {give: "hello", want: "hello"},
+		"color":    {give: FgRed.Wrap("x"), want: "[FgRed]x[Reset]"},
+		"combined": {give: FgRed.With(Bold).String() + "x", want: "[FgRed][Bold]x"},
+		"params":   {give: "\x1b[1;4;107mx\x1b[m", want: "[Bold][Underline][BgHiWhite]x[Reset]"},
+		"256":      {give: Fg256(208).String() + Bg256(17).String(), want: "[Fg256 208][Bg256 17]"},
+		"rgb":      {give: RGB{R: 255, G: 128}.Fg().String(), want: "[FgRGB #ff8000]"},
+		"bg rgb":   {give: "\x1b[48;2;0;0;255m", want: "[BgRGB #0000ff]"},
+		"bad rgb":  {give: "\x1b[48;2;0;0;300;1m", want: "[SGR 48;2;0;0;300][Bold]"},
+		"unknown":  {give: "\x1b[22;39;300;-1m", want: "[SGR 22][SGR 39][SGR 300][SGR -1]"},
+		"cursor":   {give: "\x1b[2Aa\x1b[3;4Hb\x1b[K", want: "[CUU 2]a[CUP 3;4]b[EL]"},
+		"modes":    {give: "\x1b[?25l\x1b[?2026h", want: "[DECRST 25][DECSET 2026]"},
+		"csi":      {give: "\x1b[5n", want: "[CSI 5n]"},
+		"save":     {give: "\x1b7\x1b8\x1bc", want: "[DECSC][DECRC][ESC c]"},
+		"osc 8": {
+			give: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+			want: "[OSC 8 https://example.com]link[OSC 8]",
+		},
+		"osc title": {give: "\x1b]0;my title\a", want: "[OSC 0 my title]"},
+		"dcs":       {give: "\x1bPtmux;\x1b\x1b]9;x\a\x1b\\", want: `[DCS tmux;\x1b\x1b]9;x\a]`},
+		"truncated": {give: "a\x1b[3", want: "a[CSI 3]"},
+		"esc":       {give: "a\x1b", want: "a[ESC]"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, Debug(tt.give))
+		})
+	}
+}
+
+func TestDebugWriter(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		w   = NewDebugWriter(&buf)
+	)
+
+	for _, chunk := range []string{"a\x1b", "[3", "1mb\x1b]8;;ht", "tp://x\a", "c\x1b[1"} {
+		n, err := w.Write([]byte(chunk))
+		require.NoError(t, err)
+		require.Equal(t, len(chunk), n)
+	}
+	require.Equal(t, "a[FgRed]b[OSC 8 http://x]c", buf.String())
+
+	require.NoError(t, w.Flush())
+	require.Equal(t, "a[FgRed]b[OSC 8 http://x]c[CSI 1]", buf.String())
+	require.NoError(t, w.Flush())
+
+	buf.Reset()
+	_, err := Copy(FgGreen, w, strings.NewReader("copied"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.Equal(t, "[FgGreen]copied[Reset]", buf.String())
+}
+
+func TestDebugWriter_Error(t *testing.T) {
+	w := NewDebugWriter(errWriter{})
+
+	n, err := w.Write([]byte("x"))
+	require.Error(t, err)
+	require.Zero(t, n)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// A Depth is the number of colors a terminal supports.
+type Depth int
+
+// Color depths.
+const (
+	// DepthNone is no color support.
+	DepthNone Depth = iota
+	// Depth16 is support for the 16 basic colors, e.g. [FgRed].
+	Depth16
+	// Depth256 is support for the 256-color palette, e.g. [Fg256].
+	Depth256
+	// DepthTrueColor is support for 24-bit color, e.g. [RGB.Fg].
+	DepthTrueColor
+)
+
+// String returns a human-readable name for d.
+func (d Depth) String() string {
+	switch d {
+	case DepthNone:
+		return "none"
+	case Depth16:
+		return "16"
+	case Depth256:
+		return "256"
+	case DepthTrueColor:
+		return "truecolor"
+	default:
+		return "unknown"
+	}
+}
+
+// DepthFor returns the color depth supported by the terminal that w writes
+// to, as indicated by the COLORTERM and TERM environment variables and the
+// terminal multiplexer in use, if any (see [DetectMultiplexer]). It returns
+// [DepthNone] if color is not enabled for w (see [EnabledFor]).
+func DepthFor(w io.Writer) Depth {
+	if !EnabledFor(w) {
+		return DepthNone
+	}
+	return envDepth()
+}
+
+// envDepth returns the color depth indicated by the environment.
+func envDepth() Depth {
+	depth := termDepth()
+
+	switch DetectMultiplexer() {
+	case Tmux:
+		// tmux always supports the 256-color palette, translating it for the
+		// outer terminal as needed, regardless of what TERM says.
+		return max(depth, Depth256)
+	case Screen:
+		// GNU screen does not pass 24-bit color through, even if COLORTERM
+		// was inherited from an outer terminal that supports it.
+		return min(depth, Depth256)
+	default:
+		return depth
+	}
+}
+
+// termDepth returns the color depth indicated by the COLORTERM and TERM
+// environment variables.
+func termDepth() Depth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return DepthTrueColor
+	default:
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case strings.HasSuffix(term, "-direct"):
+		return DepthTrueColor
+	case strings.Contains(term, "256color"):
+		return Depth256
+	default:
+		return Depth16
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepth_String(t *testing.T) {
+	require.Equal(t, "none", DepthNone.String())
+	require.Equal(t, "16", Depth16.String())
+	require.Equal(t, "256", Depth256.String())
+	require.Equal(t, "truecolor", DepthTrueColor.String())
+	require.Equal(t, "unknown", Depth(-1).String())
+}
+
+func TestDepthFor(t *testing.T) {
+	cases := []struct {
+		colorterm string
+		term      string
+		want      Depth
+	}{
+		{term: "xterm", want: Depth16},
+		{term: "xterm-256color", want: Depth256},
+		{term: "screen-256color", want: Depth256},
+		{term: "xterm-direct", want: DepthTrueColor},
+		{colorterm: "truecolor", term: "xterm", want: DepthTrueColor},
+		{colorterm: "24BIT", term: "xterm-256color", want: DepthTrueColor},
+		{colorterm: "yes", term: "xterm-256color", want: Depth256},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.colorterm+"/"+tt.term, func(t *testing.T) {
+			clearMultiplexer(t)
+			t.Setenv("COLORTERM", tt.colorterm)
+			t.Setenv("TERM", tt.term)
+			require.Equal(t, tt.want, envDepth())
+		})
+	}
+
+	clearMultiplexer(t)
+	t.Setenv("COLORTERM", "truecolor")
+	t.Cleanup(OverrideEnabled(false))
+	require.Equal(t, DepthNone, DepthFor(&bytes.Buffer{}))
+
+	t.Cleanup(OverrideEnabled(true))
+	require.Equal(t, DepthTrueColor, DepthFor(&bytes.Buffer{}))
+}
+
+func TestDepthFor_Multiplexer(t *testing.T) {
+	cases := []struct {
+		name      string
+		tmux      string
+		sty       string
+		colorterm string
+		term      string
+		want      Depth
+	}{
+		{name: "tmux screen", tmux: "/tmp/tmux", term: "screen", want: Depth256},
+		{name: "tmux 256", tmux: "/tmp/tmux", term: "tmux-256color", want: Depth256},
+		{
+			name:      "tmux truecolor",
+			tmux:      "/tmp/tmux",
+			colorterm: "truecolor",
+			term:      "tmux-256color",
+			want:      DepthTrueColor,
+		},
+		{name: "screen", sty: "1.pts", term: "screen", want: Depth16},
+		{name: "screen 256", sty: "1.pts", term: "screen-256color", want: Depth256},
+		{
+			name:      "screen truecolor",
+			sty:       "1.pts",
+			colorterm: "truecolor",
+			term:      "screen-256color",
+			want:      Depth256,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("STY", tt.sty)
+			t.Setenv("COLORTERM", tt.colorterm)
+			t.Setenv("TERM", tt.term)
+			require.Equal(t, tt.want, envDepth())
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// An op is a single edit. For opEqual and opDelete, idx is an index into the
+// old sequence; for opInsert, it is an index into the new sequence.
+type op struct {
+	kind opKind
+	idx  int
+}
+
+// myers returns the shortest edit script that transforms a into b, using the
+// linear-space variant of Myers' O(ND) algorithm: rather than recording every
+// intermediate frontier, it finds the middle snake of the edit graph and
+// recurses on either side of it, so memory stays O(N+M).
+func myers[T comparable](a []T, b []T) []op {
+	var (
+		size = len(a) + len(b) + 3
+		m    = &myersState[T]{
+			a:      a,
+			b:      b,
+			vf:     make([]int, 2*size),
+			vb:     make([]int, 2*size),
+			offset: size,
+		}
+	)
+
+	m.compare(0, len(a), 0, len(b))
+	return m.ops
+}
+
+type myersState[T comparable] struct {
+	a      []T
+	b      []T
+	vf     []int // furthest forward x per diagonal
+	vb     []int // furthest backward x per diagonal, from the end
+	offset int
+	ops    []op
+}
+
+// compare appends the edit script for a[aLo:aHi] -> b[bLo:bHi] to m.ops.
+func (m *myersState[T]) compare(aLo int, aHi int, bLo int, bHi int) {
+	for aLo < aHi && bLo < bHi && m.a[aLo] == m.b[bLo] {
+		m.ops = append(m.ops, op{kind: opEqual, idx: aLo})
+		aLo++
+		bLo++
+	}
+
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix &&
+		m.a[aHi-suffix-1] == m.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			m.ops = append(m.ops, op{kind: opInsert, idx: y})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			m.ops = append(m.ops, op{kind: opDelete, idx: x})
+		}
+	default:
+		x, y, u, v := m.middleSnake(aLo, aHi, bLo, bHi)
+		m.compare(aLo, aLo+x, bLo, bLo+y)
+		for i := aLo + x; i < aLo+u; i++ {
+			m.ops = append(m.ops, op{kind: opEqual, idx: i})
+		}
+		m.compare(aLo+u, aHi, bLo+v, bHi)
+	}
+
+	for i := aHi; i < aHi+suffix; i++ {
+		m.ops = append(m.ops, op{kind: opEqual, idx: i})
+	}
+}
+
+// middleSnake returns the start (x, y) and end (u, v) of the middle snake of
+// a shortest edit script for a[aLo:aHi] -> b[bLo:bHi], relative to (aLo, bLo).
+// Both ranges must be non-empty.
+func (m *myersState[T]) middleSnake(
+	aLo int,
+	aHi int,
+	bLo int,
+	bHi int,
+) (x int, y int, u int, v int) {
+	var (
+		n, mm = aHi - aLo, bHi - bLo
+		delta = n - mm
+		odd   = delta&1 != 0
+		vf    = m.vf
+		vb    = m.vb
+		off   = m.offset
+	)
+
+	vf[off+1] = 0
+	vb[off+1] = 0
+
+	for d := 0; d <= (n+mm+1)/2; d++ {
+		// Extend the forward frontier by one edit.
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < mm && m.a[aLo+u] == m.b[bLo+v] {
+				u++
+				v++
+			}
+			vf[off+k] = u
+
+			// Diagonal k in forward coordinates is delta-k backwards.
+			if odd && k >= delta-(d-1) && k <= delta+(d-1) && u+vb[off+delta-k] >= n {
+				return x, y, u, v
+			}
+		}
+
+		// Extend the backward frontier by one edit.
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < mm && m.a[aHi-1-u] == m.b[bHi-1-v] {
+				u++
+				v++
+			}
+			vb[off+k] = u
+
+			if !odd && k >= delta-d && k <= delta+d && u+vf[off+delta-k] >= n {
+				return n - u, mm - v, n - x, mm - y
+			}
+		}
+	}
+
+	// Unreachable: the frontiers always meet within (n+m+1)/2 edits.
+	return 0, 0, 0, 0
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMyers(t *testing.T) {
+	cases := []struct {
+		a     string
+		b     string
+		edits int
+	}{
+		{a: "", b: "", edits: 0},
+		{a: "abc", b: "abc", edits: 0},
+		{a: "", b: "abc", edits: 3},
+		{a: "abc", b: "", edits: 3},
+		{a: "abcabba", b: "cbabac", edits: 5},
+		{a: "kitten", b: "sitting", edits: 5},
+	}
+
+	for _, tt := range cases {
+		var (
+			a        = strings.Split(tt.a, "")
+			b        = strings.Split(tt.b, "")
+			ops      = myers(a, b)
+			gotA     strings.Builder
+			gotB     strings.Builder
+			numEdits int
+		)
+
+		for _, o := range ops {
+			switch o.kind {
+			case opEqual:
+				gotA.WriteString(a[o.idx])
+				gotB.WriteString(a[o.idx])
+			case opDelete:
+				gotA.WriteString(a[o.idx])
+				numEdits++
+			case opInsert:
+				gotB.WriteString(b[o.idx])
+				numEdits++
+			}
+		}
+
+		require.Equal(t, tt.a, gotA.String())
+		require.Equal(t, tt.b, gotB.String())
+		require.Equal(t, tt.edits, numEdits, "%q -> %q", tt.a, tt.b)
+	}
+}
+
+func TestMyers_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randString := func() []byte {
+		buf := make([]byte, rng.Intn(40))
+		for i := range buf {
+			buf[i] = "abc"[rng.Intn(3)]
+		}
+		return buf
+	}
+
+	for i := 0; i < 500; i++ {
+		var (
+			a        = randString()
+			b        = randString()
+			gotA     []byte
+			gotB     []byte
+			numEdits int
+		)
+
+		for _, o := range myers(a, b) {
+			switch o.kind {
+			case opEqual:
+				gotA = append(gotA, a[o.idx])
+				gotB = append(gotB, a[o.idx])
+			case opDelete:
+				gotA = append(gotA, a[o.idx])
+				numEdits++
+			case opInsert:
+				gotB = append(gotB, b[o.idx])
+				numEdits++
+			}
+		}
+
+		require.Equal(t, string(a), string(gotA))
+		require.Equal(t, string(b), string(gotB))
+		require.Equal(t, len(a)+len(b)-2*lcs(a, b), numEdits, "%q -> %q", a, b)
+	}
+}
+
+func TestMyers_Large(t *testing.T) {
+	var (
+		a   = []rune(strings.Repeat("a", 5000))
+		b   = []rune(strings.Repeat("b", 5000))
+		ops = myers(a, b)
+	)
+
+	require.Len(t, ops, 10000)
+}
+
+// lcs returns the length of the longest common subsequence of a and b.
+func lcs(a []byte, b []byte) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				cur[j+1] = prev[j] + 1
+			default:
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func TestSplitWords(t *testing.T) {
+	require.Equal(
+		t,
+		[]string{"foo", "(", "bar_1", ",", "  ", "baz", ")", ")"},
+		splitWords("foo(bar_1,  baz))"),
+	)
+	require.Empty(t, splitWords(""))
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff
+
+import (
+	"go.mway.dev/color"
+)
+
+// Styles are the styles applied to the parts of a diff.
+type Styles struct {
+	// Header is applied to file headers, e.g. "diff --git", "---", and "+++"
+	// lines.
+	Header color.Style
+	// Hunk is applied to hunk markers, e.g. "@@ -1,2 +1,3 @@".
+	Hunk color.Style
+	// Added is applied to added lines.
+	Added color.Style
+	// Removed is applied to removed lines.
+	Removed color.Style
+	// Context is applied to unchanged lines.
+	Context color.Style
+	// Inserted is applied to the changed portions of added lines when
+	// intra-line highlighting is enabled.
+	Inserted color.Style
+	// Deleted is applied to the changed portions of removed lines when
+	// intra-line highlighting is enabled.
+	Deleted color.Style
+}
+
+// DefaultStyles returns the default [Styles].
+func DefaultStyles() Styles {
+	return Styles{
+		Header:   color.Bold,
+		Hunk:     color.FgCyan,
+		Added:    color.FgGreen,
+		Removed:  color.FgRed,
+		Context:  color.Nop,
+		Inserted: color.Combine(color.FgGreen, color.ReverseVideo),
+		Deleted:  color.Combine(color.FgRed, color.ReverseVideo),
+	}
+}
+
+func (s Styles) merge(dst *Styles) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: s.Header, dst: &dst.Header},
+		{src: s.Hunk, dst: &dst.Hunk},
+		{src: s.Added, dst: &dst.Added},
+		{src: s.Removed, dst: &dst.Removed},
+		{src: s.Context, dst: &dst.Context},
+		{src: s.Inserted, dst: &dst.Inserted},
+		{src: s.Deleted, dst: &dst.Deleted},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Granularity is the unit at which changes within a line are found.
+type Granularity int
+
+// Granularities.
+const (
+	// GranularityWords compares runs of word characters, runs of whitespace,
+	// and individual punctuation characters.
+	GranularityWords Granularity = iota
+	// GranularityRunes compares individual runes.
+	GranularityRunes
+)
+
+func (g Granularity) split(str string) []string {
+	if g == GranularityRunes {
+		return splitRunes(str)
+	}
+	return splitWords(str)
+}
+
+// Options configure diff colorization.
+type Options struct {
+	// Styles are the styles applied to the parts of a diff. Nil styles are
+	// left unchanged when options are merged.
+	Styles Styles
+	// IntraLine controls whether changes are highlighted within
+	// removed lines that are immediately followed by the same number of
+	// added lines.
+	IntraLine bool
+	// Granularity is the unit at which changes within a line are found.
+	Granularity Granularity
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Styles: DefaultStyles(),
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Styles.merge(&dst.Styles)
+	if o.IntraLine {
+		dst.IntraLine = o.IntraLine
+	}
+	if o.Granularity != GranularityWords {
+		dst.Granularity = o.Granularity
+	}
+}
+
+// An Option configures diff colorization.
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithStyles returns an [Option] that sets the styles applied to the parts of
+// a diff. Nil styles in styles are ignored.
+func WithStyles(styles Styles) Option {
+	return optionFunc(func(o *Options) {
+		styles.merge(&o.Styles)
+	})
+}
+
+// WithIntraLine returns an [Option] that controls whether changed words are
+// highlighted within changed lines.
+func WithIntraLine(enabled bool) Option {
+	return optionFunc(func(o *Options) {
+		o.IntraLine = enabled
+	})
+}
+
+// WithGranularity returns an [Option] that sets the unit at which changes
+// within a line are found.
+func WithGranularity(granularity Granularity) Option {
+	return optionFunc(func(o *Options) {
+		o.Granularity = granularity
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff
+
+import (
+	"go.mway.dev/color"
+)
+
+// Markers used in place of styles when color is disabled.
+const (
+	DeletedOpen   = "[-"
+	DeletedClose  = "-]"
+	InsertedOpen  = "{+"
+	InsertedClose = "+}"
+)
+
+// Inline returns a single string showing the changes needed to turn before
+// into after. Deleted text is wrapped in the Deleted style and inserted text
+// in the Inserted style; unchanged text is wrapped in the Context style. If
+// color is disabled, deleted and inserted text are instead surrounded by
+// [DeletedOpen]/[DeletedClose] and [InsertedOpen]/[InsertedClose].
+func Inline(before string, after string, opts ...Option) string {
+	var (
+		options = DefaultOptions().With(opts...)
+		a       = options.Granularity.split(before)
+		b       = options.Granularity.split(after)
+	)
+
+	return render(
+		a,
+		b,
+		myers(a, b),
+		func(opKind) bool { return true },
+		decorator(options.Styles),
+	)
+}
+
+// SideBySide returns before and after with the changes between them
+// highlighted: deleted text in the first result and inserted text in the
+// second. Styles and markers are applied as in [Inline].
+func SideBySide(before string, after string, opts ...Option) (string, string) {
+	var (
+		options  = DefaultOptions().With(opts...)
+		a        = options.Granularity.split(before)
+		b        = options.Granularity.split(after)
+		ops      = myers(a, b)
+		decorate = decorator(options.Styles)
+	)
+
+	left := render(a, b, ops, func(kind opKind) bool {
+		return kind != opInsert
+	}, decorate)
+	right := render(a, b, ops, func(kind opKind) bool {
+		return kind != opDelete
+	}, decorate)
+
+	return left, right
+}
+
+func decorator(styles Styles) func(string, opKind) string {
+	enabled := color.Enabled()
+
+	return func(str string, kind opKind) string {
+		switch kind {
+		case opDelete:
+			if !enabled {
+				return DeletedOpen + str + DeletedClose
+			}
+			return styles.Deleted.Wrap(str)
+		case opInsert:
+			if !enabled {
+				return InsertedOpen + str + InsertedClose
+			}
+			return styles.Inserted.Wrap(str)
+		default:
+			return styles.Context.Wrap(str)
+		}
+	}
+}
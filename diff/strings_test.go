@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/diff"
+)
+
+func TestInline(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		ins  = color.BgGreen
+		del  = color.Combine(color.CrossedOut, color.FgRed)
+		opts = []diff.Option{
+			diff.WithStyles(diff.Styles{Inserted: ins, Deleted: del}),
+		}
+	)
+
+	require.Equal(
+		t,
+		"the "+del.Wrap("quick")+ins.Wrap("slow")+" brown fox",
+		diff.Inline("the quick brown fox", "the slow brown fox", opts...),
+	)
+
+	require.Equal(
+		t,
+		"kitt"+del.Wrap("e")+ins.Wrap("i")+"n"+ins.Wrap("g"),
+		diff.Inline(
+			"kitten",
+			"kitting",
+			append(opts, diff.WithGranularity(diff.GranularityRunes))...,
+		),
+	)
+
+	left, right := diff.SideBySide("a b c", "a x c", opts...)
+	require.Equal(t, "a "+del.Wrap("b")+" c", left)
+	require.Equal(t, "a "+ins.Wrap("x")+" c", right)
+}
+
+func TestInline_NoColor(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	require.Equal(
+		t,
+		"port: [-8080-]{+9090+}",
+		diff.Inline("port: 8080", "port: 9090"),
+	)
+	require.Equal(
+		t,
+		"ab[-c-]{+d+}",
+		diff.Inline("abc", "abd", diff.WithGranularity(diff.GranularityRunes)),
+	)
+
+	left, right := diff.SideBySide("same", "same")
+	require.Equal(t, "same", left)
+	require.Equal(t, "same", right)
+
+	left, right = diff.SideBySide("", "new")
+	require.Equal(t, "", left)
+	require.Equal(t, "{+new+}", right)
+}
+
+func TestColorize_IntraLineRunes(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		s    = diff.DefaultStyles()
+		want = s.Removed.Wrap("-") + s.Removed.Wrap("ab") + s.Deleted.Wrap("c") + "\n" +
+			s.Added.Wrap("+") + s.Added.Wrap("ab") + s.Inserted.Wrap("d") + "\n"
+	)
+
+	require.Equal(t, want, diff.Colorize(
+		"-abc\n+abd\n",
+		diff.WithIntraLine(true),
+		diff.WithGranularity(diff.GranularityRunes),
+	))
+}
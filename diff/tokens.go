@@ -0,0 +1,152 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mway.dev/color"
+)
+
+type tokenClass int
+
+const (
+	classWord tokenClass = iota
+	classSpace
+	classPunct
+)
+
+// splitWords splits str into runs of word characters, runs of whitespace, and
+// individual punctuation characters.
+func splitWords(str string) []string {
+	var (
+		tokens []string
+		start  int
+		prev   tokenClass
+	)
+
+	for i, r := range str {
+		class := classify(r)
+		if i > start && (class != prev || class == classPunct) {
+			tokens = append(tokens, str[start:i])
+			start = i
+		}
+		prev = class
+	}
+
+	if start < len(str) {
+		tokens = append(tokens, str[start:])
+	}
+
+	return tokens
+}
+
+// splitRunes splits str into individual runes.
+func splitRunes(str string) []string {
+	tokens := make([]string, 0, utf8.RuneCountInString(str))
+	for i, r := range str {
+		tokens = append(tokens, str[i:i+utf8.RuneLen(r)])
+	}
+	return tokens
+}
+
+func classify(r rune) tokenClass {
+	switch {
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return classWord
+	case unicode.IsSpace(r):
+		return classSpace
+	default:
+		return classPunct
+	}
+}
+
+// highlight renders one side of a diff: the old side (equal and deleted
+// tokens) if kind is opDelete, or the new side (equal and inserted tokens) if
+// kind is opInsert. Equal tokens are wrapped in base and changed tokens in
+// changed.
+func highlight(
+	before []string,
+	after []string,
+	ops []op,
+	kind opKind,
+	base color.Style,
+	changed color.Style,
+) string {
+	return render(
+		before,
+		after,
+		ops,
+		func(k opKind) bool {
+			return k == opEqual || k == kind
+		},
+		func(str string, k opKind) string {
+			if k == opEqual {
+				return base.Wrap(str)
+			}
+			return changed.Wrap(str)
+		},
+	)
+}
+
+// render renders each op for which include returns true, passing each run of
+// consecutive ops of the same kind to decorate.
+func render(
+	before []string,
+	after []string,
+	ops []op,
+	include func(opKind) bool,
+	decorate func(string, opKind) string,
+) string {
+	var (
+		buf     strings.Builder
+		segment strings.Builder
+		current = opEqual
+		flush   = func() {
+			if segment.Len() > 0 {
+				buf.WriteString(decorate(segment.String(), current))
+				segment.Reset()
+			}
+		}
+	)
+
+	for _, o := range ops {
+		if !include(o.kind) {
+			continue
+		}
+
+		if o.kind != current {
+			flush()
+			current = o.kind
+		}
+
+		if o.kind == opInsert {
+			segment.WriteString(after[o.idx])
+		} else {
+			segment.WriteString(before[o.idx])
+		}
+	}
+	flush()
+
+	return buf.String()
+}
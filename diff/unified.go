@@ -0,0 +1,272 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package diff colorizes diffs using [color.Style]s.
+package diff
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+var (
+	_hunkHeader     = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+	_headerPrefixes = []string{
+		"diff ",
+		"index ",
+		"--- ",
+		"+++ ",
+		"new file mode",
+		"deleted file mode",
+		"old mode",
+		"new mode",
+		"similarity index",
+		"dissimilarity index",
+		"rename from",
+		"rename to",
+		"copy from",
+		"copy to",
+		"Binary files",
+	}
+
+	_ io.WriteCloser = (*Writer)(nil)
+)
+
+type lineKind int
+
+const (
+	kindContext lineKind = iota
+	kindHeader
+	kindHunk
+	kindAdded
+	kindRemoved
+)
+
+type line struct {
+	text string
+	eol  string
+}
+
+// Colorize returns the unified diff in text with styles applied.
+func Colorize(text string, opts ...Option) string {
+	var buf strings.Builder
+	w := NewWriter(&buf, opts...)
+	io.WriteString(w, text) //nolint:errcheck
+	w.Close()               //nolint:errcheck
+	return buf.String()
+}
+
+// A Writer colorizes a unified diff as it is written, writing the result to
+// an underlying writer. Lines are written as soon as they are complete,
+// except that when intra-line highlighting is enabled, a run of removed lines
+// is held until the added lines that follow it are known. Close must be
+// called to flush any remaining output.
+type Writer struct {
+	w       io.Writer
+	opts    Options
+	partial []byte
+	oldLeft int
+	newLeft int
+	removed []line
+	added   []line
+	err     error
+}
+
+// NewWriter creates a new [Writer] that writes to w.
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	return &Writer{
+		w:    w,
+		opts: DefaultOptions().With(opts...),
+	}
+}
+
+// Write colorizes each complete line in p.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	w.partial = append(w.partial, p...)
+	for w.err == nil {
+		idx := bytes.IndexByte(w.partial, '\n')
+		if idx < 0 {
+			break
+		}
+		w.line(string(w.partial[:idx]), "\n")
+		w.partial = w.partial[idx+1:]
+	}
+
+	if len(w.partial) == 0 {
+		w.partial = nil
+	}
+
+	return len(p), w.err
+}
+
+// Close flushes any remaining output. It does not close the underlying
+// writer.
+func (w *Writer) Close() error {
+	if len(w.partial) > 0 {
+		w.line(string(w.partial), "")
+		w.partial = nil
+	}
+	w.flush()
+	return w.err
+}
+
+func (w *Writer) line(text string, eol string) {
+	if strings.HasSuffix(text, "\r") {
+		text, eol = text[:len(text)-1], "\r"+eol
+	}
+
+	ln := line{text: text, eol: eol}
+	switch kind := w.classify(text); {
+	case kind == kindRemoved && w.opts.IntraLine:
+		if len(w.added) > 0 {
+			w.flush()
+		}
+		w.removed = append(w.removed, ln)
+	case kind == kindAdded && w.opts.IntraLine && len(w.removed) > 0:
+		w.added = append(w.added, ln)
+	default:
+		w.flush()
+		w.emit(w.style(kind).Wrap(text) + eol)
+	}
+}
+
+// classify returns the kind of text, tracking the number of lines remaining
+// in the current hunk.
+func (w *Writer) classify(text string) lineKind {
+	var first byte
+	if len(text) > 0 {
+		first = text[0]
+	}
+
+	if w.oldLeft > 0 || w.newLeft > 0 {
+		switch first {
+		case '-':
+			w.oldLeft--
+			return kindRemoved
+		case '+':
+			w.newLeft--
+			return kindAdded
+		case ' ', 0:
+			w.oldLeft--
+			w.newLeft--
+			return kindContext
+		}
+	}
+
+	if m := _hunkHeader.FindStringSubmatch(text); m != nil {
+		w.oldLeft, w.newLeft = hunkCount(m[1]), hunkCount(m[2])
+		return kindHunk
+	}
+
+	for _, prefix := range _headerPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return kindHeader
+		}
+	}
+
+	switch first {
+	case '-':
+		return kindRemoved
+	case '+':
+		return kindAdded
+	default:
+		return kindContext
+	}
+}
+
+func (w *Writer) style(kind lineKind) color.Style {
+	styles := w.opts.Styles
+	switch kind {
+	case kindHeader:
+		return styles.Header
+	case kindHunk:
+		return styles.Hunk
+	case kindAdded:
+		return styles.Added
+	case kindRemoved:
+		return styles.Removed
+	default:
+		return styles.Context
+	}
+}
+
+// flush writes any held removed and added lines, highlighting changed words
+// if each removed line has a corresponding added line.
+func (w *Writer) flush() {
+	defer func() {
+		w.removed, w.added = w.removed[:0], w.added[:0]
+	}()
+
+	if len(w.removed) != len(w.added) {
+		for _, ln := range w.removed {
+			w.emit(w.opts.Styles.Removed.Wrap(ln.text) + ln.eol)
+		}
+		for _, ln := range w.added {
+			w.emit(w.opts.Styles.Added.Wrap(ln.text) + ln.eol)
+		}
+		return
+	}
+
+	var (
+		styles = w.opts.Styles
+		news   = make([]string, len(w.added))
+	)
+
+	for i := range w.removed {
+		var (
+			before = w.opts.Granularity.split(w.removed[i].text[1:])
+			after  = w.opts.Granularity.split(w.added[i].text[1:])
+			ops    = myers(before, after)
+			del    = highlight(before, after, ops, opDelete, styles.Removed, styles.Deleted)
+			ins    = highlight(before, after, ops, opInsert, styles.Added, styles.Inserted)
+		)
+
+		w.emit(styles.Removed.Wrap("-") + del + w.removed[i].eol)
+		news[i] = styles.Added.Wrap("+") + ins + w.added[i].eol
+	}
+
+	for _, str := range news {
+		w.emit(str)
+	}
+}
+
+func (w *Writer) emit(str string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, str)
+}
+
+func hunkCount(str string) int {
+	if len(str) == 0 {
+		return 1
+	}
+	n, _ := strconv.Atoi(str) //nolint:errcheck
+	return n
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package diff_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/diff"
+)
+
+const _patch = `diff --git a/foo.go b/foo.go
+index 1234567..89abcde 100644
+--- a/foo.go
++++ b/foo.go
+@@ -1,4 +1,4 @@ package foo
+ package foo
+--- not a header
+-var x = 1
++var x = 2
+\ No newline at end of file
+`
+
+func TestColorize(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		styles = diff.DefaultStyles()
+		want   = strings.Join([]string{
+			styles.Header.Wrap("diff --git a/foo.go b/foo.go"),
+			styles.Header.Wrap("index 1234567..89abcde 100644"),
+			styles.Header.Wrap("--- a/foo.go"),
+			styles.Header.Wrap("+++ b/foo.go"),
+			styles.Hunk.Wrap("@@ -1,4 +1,4 @@ package foo"),
+			styles.Context.Wrap(" package foo"),
+			styles.Removed.Wrap("--- not a header"),
+			styles.Removed.Wrap("-var x = 1"),
+			styles.Added.Wrap("+var x = 2"),
+			styles.Context.Wrap(`\ No newline at end of file`),
+		}, "\n") + "\n"
+	)
+
+	require.Equal(t, want, diff.Colorize(_patch))
+
+	// Written byte-by-byte.
+	var buf bytes.Buffer
+	w := diff.NewWriter(&buf)
+	for i := 0; i < len(_patch); i++ {
+		n, err := w.Write([]byte{_patch[i]})
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	}
+	require.NoError(t, w.Close())
+	require.Equal(t, want, buf.String())
+}
+
+func TestColorize_NoColor(t *testing.T) {
+	colortest.ForceColor(t, false)
+	require.Equal(t, _patch, diff.Colorize(_patch, diff.WithIntraLine(true)))
+}
+
+func TestColorize_Loose(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	styles := diff.DefaultStyles()
+	require.Equal(
+		t,
+		styles.Removed.Wrap("-a")+"\r\n"+styles.Added.Wrap("+b")+"\r\n"+styles.Context.Wrap("c"),
+		diff.Colorize("-a\r\n+b\r\nc"),
+	)
+}
+
+func TestColorize_IntraLine(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		del  = color.FgHiRed
+		ins  = color.FgHiGreen
+		opts = []diff.Option{
+			diff.WithIntraLine(true),
+			diff.WithStyles(diff.Styles{Inserted: ins, Deleted: del}),
+		}
+		s     = diff.DefaultStyles()
+		input = strings.Join([]string{
+			"@@ -1,3 +1,3 @@",
+			"-foo(a, b)",
+			"-x",
+			"+foo(a, c)",
+			"+y",
+			" ctx",
+			"",
+		}, "\n")
+	)
+
+	require.Equal(t, strings.Join([]string{
+		s.Hunk.Wrap("@@ -1,3 +1,3 @@"),
+		s.Removed.Wrap("-") + s.Removed.Wrap("foo(a, ") + del.Wrap("b") + s.Removed.Wrap(")"),
+		s.Removed.Wrap("-") + del.Wrap("x"),
+		s.Added.Wrap("+") + s.Added.Wrap("foo(a, ") + ins.Wrap("c") + s.Added.Wrap(")"),
+		s.Added.Wrap("+") + ins.Wrap("y"),
+		s.Context.Wrap(" ctx"),
+		"",
+	}, "\n"), diff.Colorize(input, opts...))
+
+	// Unbalanced blocks are not highlighted.
+	require.Equal(t, strings.Join([]string{
+		s.Removed.Wrap("-a"),
+		s.Added.Wrap("+b"),
+		s.Added.Wrap("+c"),
+		s.Removed.Wrap("-d"),
+	}, "\n"), diff.Colorize("-a\n+b\n+c\n-d", opts...))
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriter_Error(t *testing.T) {
+	w := diff.NewWriter(errWriter{})
+
+	_, err := w.Write([]byte("a\nb\n"))
+	require.Error(t, err)
+
+	_, err = w.Write([]byte("c\n"))
+	require.Error(t, err)
+	require.Error(t, w.Close())
+}
+
+func TestOptions(t *testing.T) {
+	opts := diff.DefaultOptions().With(diff.Options{
+		Styles:      diff.Styles{Hunk: color.FgMagenta},
+		IntraLine:   true,
+		Granularity: diff.GranularityRunes,
+	})
+
+	require.True(t, opts.IntraLine)
+	require.Equal(t, diff.GranularityRunes, opts.Granularity)
+	require.Equal(t, color.FgMagenta, opts.Styles.Hunk)
+	require.Equal(t, color.FgGreen, opts.Styles.Added)
+}
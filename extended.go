@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import "strconv"
+
+// Fg256 returns a [Style] that sets the foreground to the color at index n of
+// the terminal's 256-color palette.
+func Fg256(n uint8) Style {
+	return SGR("\x1b[38;5;" + strconv.Itoa(int(n)) + "m")
+}
+
+// Bg256 returns a [Style] that sets the background to the color at index n of
+// the terminal's 256-color palette.
+func Bg256(n uint8) Style {
+	return SGR("\x1b[48;5;" + strconv.Itoa(int(n)) + "m")
+}
+
+// An ExtendedColor is a color set by an extended SGR color code (38 or 48).
+type ExtendedColor struct {
+	// Index is the color's index in the 256-color palette, or -1 if it was
+	// specified as RGB.
+	Index int
+	// RGB is the color's value. For indexed colors, this is its value in the
+	// [DefaultPalette].
+	RGB RGB
+}
+
+// ParseExtendedColor parses the parameters that follow an extended SGR color
+// code (38 or 48), such as ["5", "208"] or ["2", "255", "135", "0"]. It
+// returns the color, the number of parameters consumed, and whether they
+// specified a valid color.
+func ParseExtendedColor(params []string) (ExtendedColor, int, bool) {
+	if len(params) >= 2 && params[0] == "5" {
+		n, err := strconv.ParseUint(params[1], 10, 8)
+		if err != nil {
+			return ExtendedColor{}, 2, false
+		}
+		return ExtendedColor{
+			Index: int(n),
+			RGB:   DefaultPalette().Indexed(uint8(n)),
+		}, 2, true
+	}
+
+	if len(params) >= 4 && params[0] == "2" {
+		var rgb [3]uint8
+		for i := range rgb {
+			n, err := strconv.ParseUint(params[i+1], 10, 8)
+			if err != nil {
+				return ExtendedColor{}, 4, false
+			}
+			rgb[i] = uint8(n)
+		}
+		return ExtendedColor{
+			Index: -1,
+			RGB:   RGB{R: rgb[0], G: rgb[1], B: rgb[2]},
+		}, 4, true
+	}
+
+	return ExtendedColor{}, 0, false
+}
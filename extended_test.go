@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedStyles(t *testing.T) {
+	cases := map[string]struct {
+		style Style
+		code  string
+	}{
+		"Fg256":  {style: Fg256(208), code: "38;5;208"},
+		"Bg256":  {style: Bg256(0), code: "48;5;0"},
+		"RGB.Fg": {style: RGB{R: 1, G: 2, B: 3}.Fg(), code: "38;2;1;2;3"},
+		"RGB.Bg": {style: RGB{R: 255, G: 128}.Bg(), code: "48;2;255;128;0"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				style = tt.style
+				esc   = "\x1b[" + tt.code + "m"
+				wrap  = func(str string) string {
+					return esc + str + Reset.String()
+				}
+			)
+
+			require.Equal(t, tt.code, style.Code())
+			require.Equal(t, esc, style.String())
+			require.Equal(t, esc, style.Escape())
+			require.Equal(t, Reset.String(), style.Reset())
+			require.Equal(t, wrap("x"), style.Wrap("x"))
+			require.Equal(t, wrap("a")+","+wrap("b"), style.Join([]string{"a", "b"}, ","))
+			require.Equal(t, wrap("a1"), style.Sprint("a", 1))
+			require.Equal(t, wrap("a=1"), style.Sprintf("a=%d", 1))
+			require.Equal(t, wrap("a 1")+"\n", style.Sprintln("a", 1))
+			require.Equal(t, style, style.With())
+			require.Equal(t, "\x1b["+tt.code+";1m", style.With(Bold).String())
+			require.Equal(t, "\x1b[1;"+tt.code+"m", Bold.With(style).String())
+
+			var buf bytes.Buffer
+			n, err := style.Fprint(&buf, "x")
+			require.NoError(t, err)
+			require.Equal(t, buf.Len(), n)
+			require.Equal(t, wrap("x"), buf.String())
+
+			buf.Reset()
+			_, err = style.Fprintf(&buf, "%d", 2)
+			require.NoError(t, err)
+			require.Equal(t, wrap("2"), buf.String())
+
+			buf.Reset()
+			_, err = style.Fprintln(&buf, "y")
+			require.NoError(t, err)
+			require.Equal(t, wrap("y")+"\n", buf.String())
+
+			buf.Reset()
+			n64, err := style.Copy(&buf, strings.NewReader("z"))
+			require.NoError(t, err)
+			require.Equal(t, int64(buf.Len()), n64)
+			require.Equal(t, wrap("z"), buf.String())
+		})
+	}
+}
+
+func TestParseExtendedColor(t *testing.T) {
+	cases := map[string]struct {
+		give []string
+		want ExtendedColor
+		n    int
+		ok   bool
+	}{
+		"indexed": {
+			give: []string{"5", "208", "1"},
+			want: ExtendedColor{Index: 208, RGB: RGB{R: 255, G: 135}},
+			n:    2,
+			ok:   true,
+		},
+		"rgb": {
+			give: []string{"2", "1", "2", "3"},
+			want: ExtendedColor{Index: -1, RGB: RGB{R: 1, G: 2, B: 3}},
+			n:    4,
+			ok:   true,
+		},
+		"bad index": {give: []string{"5", "256"}, n: 2},
+		"bad rgb":   {give: []string{"2", "1", "2", "300"}, n: 4},
+		"short":     {give: []string{"2", "1", "2"}},
+		"unknown":   {give: []string{"3", "1"}},
+		"empty":     {},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, n, ok := ParseExtendedColor(tt.give)
+			require.Equal(t, tt.want, c)
+			require.Equal(t, tt.n, n)
+			require.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestExtendedStyles_Disabled(t *testing.T) {
+	t.Cleanup(OverrideEnabled(false))
+
+	style := Fg256(1)
+	require.Equal(t, "", style.Escape())
+	require.Equal(t, "", style.Reset())
+	require.Equal(t, "x", style.Sprint("x"))
+	require.Equal(t, "\x1b[38;5;1m", style.String())
+}
@@ -9,11 +9,11 @@ require (
 	github.com/stretchr/testify v1.8.0
 	go.mway.dev/errors v0.4.0
 	go.mway.dev/pool v0.1.1
+	golang.org/x/sys v0.16.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/sys v0.16.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
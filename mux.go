@@ -0,0 +1,146 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"os"
+	"strings"
+)
+
+// _screenChunk is the maximum number of bytes that are passed through GNU
+// screen in a single DCS sequence, as screen limits the length of a DCS.
+const _screenChunk = 512
+
+// A Multiplexer is a terminal multiplexer, such as tmux, that sits between a
+// program and the terminal it is displayed in.
+type Multiplexer int
+
+// Terminal multiplexers.
+const (
+	// NoMultiplexer is a program running directly in a terminal.
+	NoMultiplexer Multiplexer = iota
+	// Tmux is tmux.
+	Tmux
+	// Screen is GNU screen.
+	Screen
+)
+
+// String returns a human-readable name for m.
+func (m Multiplexer) String() string {
+	switch m {
+	case NoMultiplexer:
+		return "none"
+	case Tmux:
+		return "tmux"
+	case Screen:
+		return "screen"
+	default:
+		return "unknown"
+	}
+}
+
+// DetectMultiplexer returns the terminal multiplexer that the program is
+// running in, as indicated by the TMUX, STY, and TERM environment variables.
+func DetectMultiplexer() Multiplexer {
+	switch {
+	case os.Getenv("TMUX") != "":
+		return Tmux
+	case os.Getenv("STY") != "":
+		return Screen
+	case strings.HasPrefix(os.Getenv("TERM"), "tmux"):
+		// TMUX is not forwarded to remote hosts, but TERM usually is.
+		return Tmux
+	default:
+		return NoMultiplexer
+	}
+}
+
+// Passthrough wraps the escape sequences in str for passthrough by the
+// multiplexer that the program is running in, if any (see
+// [DetectMultiplexer] and [Multiplexer.Passthrough]). CSI sequences, such as
+// SGR and cursor sequences, are left as is, since the multiplexer must
+// interpret them itself, as are DCS sequences, which may already be wrapped.
+// Text between sequences is also left as is.
+func Passthrough(str string) string {
+	m := DetectMultiplexer()
+	if m == NoMultiplexer || strings.IndexByte(str, _esc) < 0 {
+		return str
+	}
+
+	var buf strings.Builder
+	for len(str) > 0 {
+		i := strings.IndexByte(str, _esc)
+		if i < 0 {
+			buf.WriteString(str)
+			break
+		}
+
+		buf.WriteString(str[:i])
+		str = str[i:]
+
+		n := escapeLen(str)
+		switch seq := str[:n]; {
+		case len(seq) < 2, seq[1] == '[', seq[1] == 'P':
+			buf.WriteString(seq)
+		case seq[1] == ']', seq[1] == 'X', seq[1] == '^', seq[1] == '_':
+			buf.WriteString(m.Passthrough(seq))
+		default:
+			buf.WriteString(seq)
+		}
+		str = str[n:]
+	}
+	return buf.String()
+}
+
+// Passthrough wraps seq in m's DCS passthrough sequence, so that m forwards
+// it to the outer terminal rather than interpreting (or discarding) it. This
+// is needed for sequences m does not support itself, such as many OSC
+// sequences, but not for SGR or cursor sequences, which m must interpret to
+// keep its own screen up to date. tmux 3.3 and later also requires the
+// allow-passthrough option to be enabled. For [NoMultiplexer], seq is
+// returned unchanged.
+func (m Multiplexer) Passthrough(seq string) string {
+	if len(seq) == 0 {
+		return seq
+	}
+
+	switch m {
+	case Tmux:
+		// tmux requires escape characters within the sequence to be doubled.
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case Screen:
+		var buf strings.Builder
+		for len(seq) > 0 {
+			n := min(len(seq), _screenChunk)
+			// Avoid ending a chunk with an escape character, which screen
+			// would read as the start of the chunk's terminator.
+			for n < len(seq) && n > 1 && seq[n-1] == _esc {
+				n--
+			}
+
+			buf.WriteString("\x1bP" + seq[:n] + "\x1b\\")
+			seq = seq[n:]
+		}
+		return buf.String()
+	default:
+		return seq
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiplexer_String(t *testing.T) {
+	require.Equal(t, "none", NoMultiplexer.String())
+	require.Equal(t, "tmux", Tmux.String())
+	require.Equal(t, "screen", Screen.String())
+	require.Equal(t, "unknown", Multiplexer(99).String())
+}
+
+func TestMultiplexer_Passthrough(t *testing.T) {
+	const seq = "\x1b]52;c;aGk=\x1b\\"
+
+	require.Equal(t, seq, NoMultiplexer.Passthrough(seq))
+	require.Equal(t, "", Tmux.Passthrough(""))
+	require.Equal(
+		t,
+		"\x1bPtmux;\x1b\x1b]52;c;aGk=\x1b\x1b\\\x1b\\",
+		Tmux.Passthrough(seq),
+	)
+	require.Equal(t, "\x1bP"+seq+"\x1b\\", Screen.Passthrough(seq))
+}
+
+func TestMultiplexer_PassthroughScreenChunks(t *testing.T) {
+	seq := "\x1b]52;c;" + strings.Repeat("a", _screenChunk-8) + "\x1bb" + strings.Repeat("c", 600)
+
+	got := Screen.Passthrough(seq)
+	chunks := strings.Split(strings.TrimSuffix(got, "\x1b\\"), "\x1b\\\x1bP")
+	require.Len(t, chunks, 3)
+
+	var joined strings.Builder
+	for i, chunk := range chunks {
+		if i == 0 {
+			chunk = strings.TrimPrefix(chunk, "\x1bP")
+		}
+		require.LessOrEqual(t, len(chunk), _screenChunk)
+		require.False(t, strings.HasSuffix(chunk, "\x1b"))
+		joined.WriteString(chunk)
+	}
+	require.Equal(t, seq, joined.String())
+}
+
+func TestDetectMultiplexer(t *testing.T) {
+	cases := []struct {
+		name string
+		tmux string
+		sty  string
+		term string
+		want Multiplexer
+	}{
+		{name: "none", term: "xterm-256color", want: NoMultiplexer},
+		{name: "tmux", tmux: "/tmp/tmux-0/default,1,0", term: "screen", want: Tmux},
+		{name: "screen", sty: "1234.pts-0.host", term: "screen", want: Screen},
+		{name: "tmux term", term: "tmux-256color", want: Tmux},
+		{name: "screen term", term: "screen-256color", want: NoMultiplexer},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("STY", tt.sty)
+			t.Setenv("TERM", tt.term)
+			require.Equal(t, tt.want, DetectMultiplexer())
+		})
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	const str = "a\x1b[31mb\x1b[0m\x1b]0;t\a\x1b[2Ac\x1bPq\x1b\\\x1b7"
+
+	clearMultiplexer(t)
+	require.Equal(t, str, Passthrough(str))
+
+	t.Setenv("TMUX", "/tmp/tmux")
+	require.Equal(
+		t,
+		"a\x1b[31mb\x1b[0m\x1bPtmux;\x1b\x1b]0;t\a\x1b\\\x1b[2Ac\x1bPq\x1b\\\x1b7",
+		Passthrough(str),
+	)
+	require.Equal(t, "plain", Passthrough("plain"))
+
+	clearMultiplexer(t)
+	t.Setenv("STY", "1.pts")
+	require.Equal(t, "\x1bP\x1b]9;x\a\x1b\\", Passthrough("\x1b]9;x\a"))
+}
+
+func clearMultiplexer(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	t.Setenv("TERM", "xterm")
+}
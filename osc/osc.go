@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package osc provides operating system command (OSC) sequences for setting
+// the window title, sending desktop notifications, and copying to the
+// clipboard.
+package osc
+
+import (
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"go.mway.dev/color"
+	"go.mway.dev/errors"
+)
+
+// MaxClipboard is the maximum number of bytes that can be copied with
+// [Copy]. Many terminals ignore OSC 52 sequences with more than 100,000
+// bytes of base64-encoded data, which this is the decoded equivalent of.
+const MaxClipboard = 74994
+
+// ErrTooLarge indicates that data is too large to be copied to the clipboard.
+var ErrTooLarge = errors.New("data exceeds clipboard size limit")
+
+// A Sequence is an OSC sequence. Like a [color.Style], its [Sequence.String]
+// is unconditional, while [Sequence.Escape] and [Fprint] only produce output
+// if color (and thus terminal control) is enabled.
+type Sequence string
+
+// Title sets both the icon name and the window title to title (OSC 0).
+func Title(title string) Sequence {
+	return osc("0;" + sanitize(title))
+}
+
+// WindowTitle sets only the window title to title (OSC 2).
+func WindowTitle(title string) Sequence {
+	return osc("2;" + sanitize(title))
+}
+
+// Notify sends a desktop notification with the given message (OSC 9). This
+// is supported by iTerm2, WezTerm, Windows Terminal, and others.
+func Notify(message string) Sequence {
+	return osc("9;" + sanitize(message))
+}
+
+// NotifyTitled sends a desktop notification with the given title and body
+// (OSC 777). This is supported by rxvt-unicode, foot, Ghostty, WezTerm, and
+// others.
+func NotifyTitled(title string, body string) Sequence {
+	// Semicolons separate fields, so they cannot appear in the title.
+	title = strings.ReplaceAll(sanitize(title), ";", ",")
+	return osc("777;notify;" + title + ";" + sanitize(body))
+}
+
+// Copy copies data to the system clipboard (OSC 52). It returns
+// [ErrTooLarge] if data is longer than [MaxClipboard] bytes.
+func Copy(data []byte) (Sequence, error) {
+	if len(data) > MaxClipboard {
+		return "", ErrTooLarge
+	}
+	return osc("52;c;" + base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// String returns s, regardless of whether color is enabled.
+func (s Sequence) String() string {
+	return string(s)
+}
+
+// Escape returns s if color is enabled (see [color.Enabled]), or an empty
+// string otherwise.
+func (s Sequence) Escape() string {
+	if !color.Enabled() {
+		return ""
+	}
+	return string(s)
+}
+
+// Passthrough returns s wrapped for passthrough by m (see
+// [color.Multiplexer.Passthrough]).
+func (s Sequence) Passthrough(m color.Multiplexer) Sequence {
+	return Sequence(m.Passthrough(string(s)))
+}
+
+// Fprint writes the given sequences to w if color is enabled for w (see
+// [color.EnabledFor]), and does nothing otherwise, so that control sequences
+// are not written to files or pipes. Sequences are automatically wrapped for
+// passthrough if running in tmux or GNU screen (see [color.Passthrough]).
+func Fprint(w io.Writer, seqs ...Sequence) (int, error) {
+	if len(seqs) == 0 || !color.EnabledFor(w) {
+		return 0, nil
+	}
+
+	var buf strings.Builder
+	for _, seq := range seqs {
+		buf.WriteString(string(seq))
+	}
+	return io.WriteString(w, color.Passthrough(buf.String()))
+}
+
+// osc returns the OSC sequence with the given payload. Sequences are
+// terminated with BEL rather than ST, as BEL is more widely supported and
+// does not contain an escape character that would need to be doubled for
+// passthrough.
+func osc(payload string) Sequence {
+	return Sequence("\x1b]" + payload + "\a")
+}
+
+// sanitize removes control characters from str, which could otherwise
+// terminate the sequence early or inject other sequences.
+func sanitize(str string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r < 0xa0) {
+			return -1
+		}
+		return r
+	}, str)
+}
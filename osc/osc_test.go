@@ -0,0 +1,120 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package osc_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/osc"
+)
+
+func TestSequences(t *testing.T) {
+	cases := map[string]struct {
+		seq  osc.Sequence
+		want string
+	}{
+		"Title":          {seq: osc.Title("build"), want: "\x1b]0;build\a"},
+		"WindowTitle":    {seq: osc.WindowTitle("build"), want: "\x1b]2;build\a"},
+		"Title control":  {seq: osc.Title("a\x1b]2;b\a\u009c"), want: "\x1b]0;a]2;b\a"},
+		"Notify":         {seq: osc.Notify("done"), want: "\x1b]9;done\a"},
+		"NotifyTitled":   {seq: osc.NotifyTitled("a;b", "c;d"), want: "\x1b]777;notify;a,b;c;d\a"},
+		"Notify unicode": {seq: osc.Notify("✓ done"), want: "\x1b]9;✓ done\a"},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.seq.String())
+			require.Zero(t, color.VisibleWidth(tt.seq.String()))
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	seq, err := osc.Copy([]byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, "\x1b]52;c;aGVsbG8=\a", seq.String())
+
+	seq, err = osc.Copy(make([]byte, osc.MaxClipboard))
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(seq), 100000+len("\x1b]52;c;\a"))
+
+	_, err = osc.Copy(make([]byte, osc.MaxClipboard+1))
+	require.ErrorIs(t, err, osc.ErrTooLarge)
+}
+
+func TestSequence_Escape(t *testing.T) {
+	colortest.ForceColor(t, true)
+	require.Equal(t, "\x1b]2;x\a", osc.WindowTitle("x").Escape())
+
+	colortest.ForceColor(t, false)
+	require.Equal(t, "", osc.WindowTitle("x").Escape())
+}
+
+func TestSequence_Passthrough(t *testing.T) {
+	seq := osc.Notify("hi")
+	require.Equal(t, seq, seq.Passthrough(color.NoMultiplexer))
+	require.Equal(
+		t,
+		"\x1bPtmux;\x1b\x1b]9;hi\a\x1b\\",
+		seq.Passthrough(color.Tmux).String(),
+	)
+	require.Equal(
+		t,
+		"\x1bP\x1b]9;hi\a\x1b\\",
+		seq.Passthrough(color.Screen).String(),
+	)
+}
+
+func TestFprint(t *testing.T) {
+	colortest.ForceColor(t, true)
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	t.Setenv("TERM", "xterm")
+
+	var buf bytes.Buffer
+	n, err := osc.Fprint(&buf, osc.Title("a"), osc.Notify("b"))
+	require.NoError(t, err)
+	require.Equal(t, "\x1b]0;a\a\x1b]9;b\a", buf.String())
+	require.Equal(t, buf.Len(), n)
+
+	colortest.ForceColor(t, false)
+	buf.Reset()
+	n, err = osc.Fprint(&buf, osc.Title("a"))
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Zero(t, buf.Len())
+	require.False(t, strings.Contains(buf.String(), "\x1b"))
+}
+
+func TestFprint_Passthrough(t *testing.T) {
+	colortest.ForceColor(t, true)
+	t.Setenv("TMUX", "/tmp/tmux")
+
+	var buf bytes.Buffer
+	_, err := osc.Fprint(&buf, osc.Notify("b"))
+	require.NoError(t, err)
+	require.Equal(t, "\x1bPtmux;\x1b\x1b]9;b\a\x1b\\", buf.String())
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A Palette maps the 16 basic terminal colors (black through white, followed
+// by their high-intensity variants) to the RGB values a terminal renders them
+// as. Terminals let users configure these, so they can only be approximated.
+type Palette [16]RGB
+
+// DefaultPalette returns the default xterm [Palette].
+func DefaultPalette() Palette {
+	return Palette{
+		{R: 0, G: 0, B: 0},
+		{R: 205, G: 0, B: 0},
+		{R: 0, G: 205, B: 0},
+		{R: 205, G: 205, B: 0},
+		{R: 0, G: 0, B: 238},
+		{R: 205, G: 0, B: 205},
+		{R: 0, G: 205, B: 205},
+		{R: 229, G: 229, B: 229},
+		{R: 127, G: 127, B: 127},
+		{R: 255, G: 0, B: 0},
+		{R: 0, G: 255, B: 0},
+		{R: 255, G: 255, B: 0},
+		{R: 92, G: 92, B: 255},
+		{R: 255, G: 0, B: 255},
+		{R: 0, G: 255, B: 255},
+		{R: 255, G: 255, B: 255},
+	}
+}
+
+// Indexed returns the RGB value of the color at index n of the 256-color
+// palette: the 16 colors of p, followed by a 6x6x6 color cube and a 24-step
+// grayscale ramp.
+func (p Palette) Indexed(n uint8) RGB {
+	switch {
+	case n < 16:
+		return p[n]
+	case n < 232:
+		n -= 16
+		return RGB{R: cubeLevel(n / 36), G: cubeLevel(n / 6 % 6), B: cubeLevel(n % 6)}
+	default:
+		gray := 8 + 10*(n-232)
+		return RGB{R: gray, G: gray, B: gray}
+	}
+}
+
+// Foreground returns the foreground color set by style, if any.
+func (p Palette) Foreground(style Style) (RGB, bool) {
+	fg, _, ok, _ := p.colors(style)
+	return fg, ok
+}
+
+// Background returns the background color set by style, if any.
+func (p Palette) Background(style Style) (RGB, bool) {
+	_, bg, _, ok := p.colors(style)
+	return bg, ok
+}
+
+// colors returns the foreground and background colors set by style's SGR
+// codes, with later codes taking precedence over earlier ones.
+func (p Palette) colors(style Style) (fg RGB, bg RGB, hasFg bool, hasBg bool) {
+	if style == nil {
+		return fg, bg, false, false
+	}
+
+	codes := strings.Split(style.Code(), ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+
+		switch code {
+		case 0:
+			hasFg, hasBg = false, false
+		case 39:
+			hasFg = false
+		case 49:
+			hasBg = false
+		case 38, 48:
+			c, n, ok := p.extended(codes[i+1:])
+			i += n
+			if ok && code == 38 {
+				fg, hasFg = c, true
+			} else if ok {
+				bg, hasBg = c, true
+			}
+		default:
+			if c, isFg, ok := p.basic(code); ok && isFg {
+				fg, hasFg = c, true
+			} else if ok {
+				bg, hasBg = c, true
+			}
+		}
+	}
+
+	return fg, bg, hasFg, hasBg
+}
+
+// basic returns the color set by a basic SGR color code, and whether it is a
+// foreground color.
+func (p Palette) basic(code int) (c RGB, isFg bool, ok bool) {
+	switch {
+	case code >= 30 && code <= 37:
+		return p[code-30], true, true
+	case code >= 90 && code <= 97:
+		return p[code-90+8], true, true
+	case code >= 40 && code <= 47:
+		return p[code-40], false, true
+	case code >= 100 && code <= 107:
+		return p[code-100+8], false, true
+	default:
+		return RGB{}, false, false
+	}
+}
+
+// extended returns the color set by the arguments of an extended SGR color
+// code (38 or 48), and the number of arguments consumed.
+func (p Palette) extended(args []string) (RGB, int, bool) {
+	c, n, ok := ParseExtendedColor(args)
+	if ok && c.Index >= 0 {
+		return p.Indexed(uint8(c.Index)), n, true
+	}
+	return c.RGB, n, ok
+}
+
+func cubeLevel(n uint8) uint8 {
+	if n == 0 {
+		return 0
+	}
+	return 55 + 40*n
+}
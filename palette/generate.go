@@ -0,0 +1,145 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package palette
+
+import (
+	"math"
+
+	"go.mway.dev/color"
+)
+
+const (
+	// _gridStep is the spacing between the OKLab colors that 24-bit colors
+	// are generated from.
+	_gridStep = 0.04
+	// _gamutTolerance is the maximum OKLab distance between a generated color
+	// and its nearest RGB value; colors further away are out of gamut.
+	_gamutTolerance = 0.01
+)
+
+// Generate returns n styles whose foreground colors are as perceptually
+// distinct from one another, and from the background and reserved colors, as
+// the depth and constraints given by opts allow. Colors are chosen greedily,
+// so any prefix of the result is itself well spread. If fewer than n colors
+// are available, they are repeated in order; if none are, the result contains
+// [color.Nop] styles.
+func Generate(n int, opts ...Option) []color.Style {
+	if n <= 0 {
+		return nil
+	}
+
+	var (
+		options = DefaultOptions().With(opts...)
+		styles  = make([]color.Style, n)
+		pool    []candidate
+	)
+
+	for _, c := range generateCandidates(options) {
+		if allowed(options, c.rgb) {
+			pool = append(pool, c)
+		}
+	}
+
+	if len(pool) == 0 {
+		for i := range styles {
+			styles[i] = color.Nop
+		}
+		return styles
+	}
+
+	anchors := []color.OKLab{options.Background.OKLab()}
+	for _, style := range options.Reserved {
+		if rgb, ok := options.Palette.Foreground(style); ok {
+			anchors = append(anchors, rgb.OKLab())
+		}
+	}
+
+	picked := spread(pool, anchors, min(n, len(pool)))
+	for i := range styles {
+		styles[i] = pool[picked[i%len(picked)]].style
+	}
+
+	return styles
+}
+
+// generateCandidates returns the foreground colors available at the depth
+// given by opts, before filtering. Unlike [candidates], 24-bit colors span
+// all lightnesses and chromas rather than a single ring of hues.
+func generateCandidates(opts Options) []candidate {
+	if opts.Depth != color.DepthTrueColor {
+		return candidates(opts)
+	}
+
+	var dst []candidate
+	for l := _gridStep; l < 1; l += _gridStep {
+		if l < opts.MinLightness || l > opts.MaxLightness {
+			continue
+		}
+
+		for a := -0.32; a <= 0.32; a += _gridStep {
+			for b := -0.32; b <= 0.32; b += _gridStep {
+				var (
+					lab = color.OKLab{L: l, A: a, B: b}
+					rgb = lab.RGB()
+				)
+				if rgb.OKLab().Distance(lab) <= _gamutTolerance {
+					dst = append(dst, candidate{style: rgb.Fg(), rgb: rgb})
+				}
+			}
+		}
+	}
+
+	return dst
+}
+
+// spread greedily picks n of pool, each the candidate farthest from the
+// anchors and the candidates already picked, and returns their indexes.
+func spread(pool []candidate, anchors []color.OKLab, n int) []int {
+	var (
+		labs   = make([]color.OKLab, len(pool))
+		dists  = make([]float64, len(pool))
+		picked = make([]int, 0, n)
+	)
+
+	for i, c := range pool {
+		labs[i] = c.rgb.OKLab()
+		dists[i] = math.Inf(1)
+		for _, anchor := range anchors {
+			dists[i] = min(dists[i], labs[i].Distance(anchor))
+		}
+	}
+
+	for len(picked) < n {
+		best := 0
+		for i := range dists {
+			if dists[i] > dists[best] {
+				best = i
+			}
+		}
+
+		picked = append(picked, best)
+		for i := range dists {
+			dists[i] = min(dists[i], labs[i].Distance(labs[best]))
+		}
+	}
+
+	return picked
+}
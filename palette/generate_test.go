@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package palette_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/palette"
+)
+
+func TestGenerate(t *testing.T) {
+	require.Nil(t, palette.Generate(0))
+	require.Nil(t, palette.Generate(-1))
+
+	pal := color.DefaultPalette()
+	for _, depth := range []color.Depth{color.Depth16, color.Depth256, color.DepthTrueColor} {
+		t.Run(depth.String(), func(t *testing.T) {
+			var (
+				styles = palette.Generate(6, palette.WithDepth(depth))
+				labs   []color.OKLab
+			)
+
+			require.Len(t, styles, 6)
+			require.Equal(t, styles[:3], palette.Generate(3, palette.WithDepth(depth)))
+
+			for _, style := range styles {
+				rgb, ok := pal.Foreground(style)
+				require.True(t, ok)
+				labs = append(labs, rgb.OKLab())
+			}
+
+			for i := range labs {
+				for j := i + 1; j < len(labs); j++ {
+					require.Greater(t, labs[i].Distance(labs[j]), 0.1, "%d, %d", i, j)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_Spread(t *testing.T) {
+	// Greedy selection should beat hashing by a wide margin.
+	var (
+		pal    = color.DefaultPalette()
+		styles = palette.Generate(12, palette.WithDepth(color.DepthTrueColor))
+		labs   []color.OKLab
+	)
+
+	for _, style := range styles {
+		rgb, _ := pal.Foreground(style)
+		labs = append(labs, rgb.OKLab())
+	}
+
+	nearest := 1.0
+	for i := range labs {
+		for j := i + 1; j < len(labs); j++ {
+			nearest = min(nearest, labs[i].Distance(labs[j]))
+		}
+	}
+	require.Greater(t, nearest, 0.15)
+}
+
+func TestGenerate_Lightness(t *testing.T) {
+	pal := color.DefaultPalette()
+
+	for _, depth := range []color.Depth{color.Depth256, color.DepthTrueColor} {
+		styles := palette.Generate(
+			10,
+			palette.WithDepth(depth),
+			palette.WithLightness(0.6, 0.7),
+		)
+
+		for _, style := range styles {
+			rgb, _ := pal.Foreground(style)
+			lab := rgb.OKLab()
+			require.GreaterOrEqual(t, lab.L, 0.6-1e-3)
+			require.LessOrEqual(t, lab.L, 0.7+1e-3)
+		}
+	}
+
+	// Hashing clamps its lightness into the range.
+	hasher := palette.NewHasher(
+		palette.WithDepth(color.DepthTrueColor),
+		palette.WithLightness(0.85, 0.95),
+	)
+	require.Positive(t, hasher.Len())
+	rgb, _ := pal.Foreground(hasher.Style("x"))
+	require.InDelta(t, 0.85, rgb.OKLab().L, 0.01)
+}
+
+func TestGenerate_Repeat(t *testing.T) {
+	var (
+		available = palette.NewHasher(palette.WithDepth(color.Depth16)).Len()
+		styles    = palette.Generate(available+2, palette.WithDepth(color.Depth16))
+	)
+
+	require.Len(t, styles, available+2)
+	require.Equal(t, styles[:2], styles[available:])
+
+	seen := make(map[color.Style]struct{})
+	for _, style := range styles[:available] {
+		require.IsType(t, color.Color(0), style)
+		seen[style] = struct{}{}
+	}
+	require.Len(t, seen, available)
+}
+
+func TestGenerate_None(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	styles := palette.Generate(3, palette.WithDetectedDepth(&bytes.Buffer{}))
+	require.Equal(t, []color.Style{color.Nop, color.Nop, color.Nop}, styles)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package palette chooses sets of distinguishable colors.
+package palette
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"go.mway.dev/color"
+)
+
+const (
+	// _minChroma is the minimum chroma of chosen colors, which excludes
+	// grays that are hard to tell apart from default text.
+	_minChroma = 0.05
+	// _reservedDistance is the minimum OKLab distance between chosen colors
+	// and reserved colors.
+	_reservedDistance = 0.12
+	// _hues is the number of evenly spaced hues that 24-bit colors are chosen
+	// from.
+	_hues = 36
+)
+
+var _defaultHasher = sync.OnceValue(func() *Hasher {
+	return NewHasher()
+})
+
+// A Hasher deterministically assigns colors to keys, such as service names or
+// request IDs, so that each key is always shown in the same color. A Hasher is
+// safe for concurrent use.
+type Hasher struct {
+	styles []color.Style
+}
+
+// NewHasher creates a new [Hasher] that assigns the colors allowed by opts.
+func NewHasher(opts ...Option) *Hasher {
+	var (
+		options = DefaultOptions().With(opts...)
+		styles  []color.Style
+	)
+
+	for _, c := range candidates(options) {
+		if allowed(options, c.rgb) {
+			styles = append(styles, c.style)
+		}
+	}
+
+	return &Hasher{styles: styles}
+}
+
+// Style returns the style assigned to key. It returns [color.Nop] if the
+// hasher's options do not allow any colors.
+func (h *Hasher) Style(key string) color.Style {
+	if len(h.styles) == 0 {
+		return color.Nop
+	}
+
+	sum := fnv.New64a()
+	sum.Write([]byte(key)) //nolint:errcheck
+	return h.styles[sum.Sum64()%uint64(len(h.styles))]
+}
+
+// Len returns the number of distinct styles h assigns.
+func (h *Hasher) Len() int {
+	return len(h.styles)
+}
+
+// Hash returns the style assigned to key by a [Hasher] with the given
+// options.
+func Hash(key string, opts ...Option) color.Style {
+	if len(opts) == 0 {
+		return _defaultHasher().Style(key)
+	}
+	return NewHasher(opts...).Style(key)
+}
+
+type candidate struct {
+	style color.Style
+	rgb   color.RGB
+}
+
+// candidates returns the foreground colors available at the depth given by
+// opts, before filtering.
+func candidates(opts Options) []candidate {
+	var dst []candidate
+
+	switch opts.Depth {
+	case color.DepthNone:
+	case color.Depth16:
+		for i, fg := range []color.Color{color.FgBlack, color.FgHiBlack} {
+			for j := color.Color(0); j < 8; j++ {
+				dst = append(dst, candidate{
+					style: fg + j,
+					rgb:   opts.Palette[i*8+int(j)],
+				})
+			}
+		}
+	case color.Depth256:
+		for n := 16; n < 232; n++ {
+			dst = append(dst, candidate{
+				style: color.Fg256(uint8(n)),
+				rgb:   opts.Palette.Indexed(uint8(n)),
+			})
+		}
+	default:
+		lightness := 0.75
+		if opts.Background.Luminance() > 0.18 {
+			lightness = 0.5
+		}
+		lightness = min(max(lightness, opts.MinLightness), opts.MaxLightness)
+
+		for i := 0; i < _hues; i++ {
+			rgb := color.LCH(lightness, 0.15, float64(i)*360/_hues).RGB()
+			dst = append(dst, candidate{style: rgb.Fg(), rgb: rgb})
+		}
+	}
+
+	return dst
+}
+
+// allowed reports whether rgb is colorful, within the lightness range,
+// contrasts with the background, and is distinguishable from reserved colors.
+func allowed(opts Options, rgb color.RGB) bool {
+	lab := rgb.OKLab()
+	if lab.Chroma() < _minChroma ||
+		lab.L < opts.MinLightness || lab.L > opts.MaxLightness ||
+		color.Contrast(rgb, opts.Background) < opts.MinContrast {
+		return false
+	}
+
+	for _, style := range opts.Reserved {
+		reserved, ok := opts.Palette.Foreground(style)
+		if ok && lab.Distance(reserved.OKLab()) < _reservedDistance {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package palette_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/palette"
+)
+
+func TestHash_Deterministic(t *testing.T) {
+	for _, depth := range []color.Depth{color.Depth16, color.Depth256, color.DepthTrueColor} {
+		t.Run(depth.String(), func(t *testing.T) {
+			var (
+				hasher = palette.NewHasher(palette.WithDepth(depth))
+				seen   = make(map[string]struct{})
+			)
+
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("service-%d", i)
+				style := hasher.Style(key)
+				require.Equal(t, style, hasher.Style(key))
+				require.Equal(t, style, palette.Hash(key, palette.WithDepth(depth)))
+				seen[style.Code()] = struct{}{}
+			}
+
+			require.GreaterOrEqual(t, len(seen), min(hasher.Len(), 100)/2)
+		})
+	}
+
+	require.Equal(t, palette.Hash("a", palette.WithDepth(color.Depth256)), palette.Hash("a"))
+}
+
+func TestHash_Depth(t *testing.T) {
+	cases := map[color.Depth]string{
+		color.Depth16:        `^(3|9)[0-7]$`,
+		color.Depth256:       `^38;5;\d+$`,
+		color.DepthTrueColor: `^38;2;\d+;\d+;\d+$`,
+	}
+
+	for depth, pattern := range cases {
+		hasher := palette.NewHasher(palette.WithDepth(depth))
+		require.Positive(t, hasher.Len())
+		for i := 0; i < 50; i++ {
+			require.Regexp(t, pattern, hasher.Style(fmt.Sprint(i)).Code())
+		}
+	}
+
+	require.Equal(t, 36, palette.NewHasher(palette.WithDepth(color.DepthTrueColor)).Len())
+}
+
+func TestHash_Constraints(t *testing.T) {
+	var (
+		pal   = color.DefaultPalette()
+		white = color.RGB{R: 255, G: 255, B: 255}
+	)
+
+	for _, depth := range []color.Depth{color.Depth16, color.Depth256, color.DepthTrueColor} {
+		for _, bg := range []color.RGB{{}, white} {
+			hasher := palette.NewHasher(
+				palette.WithDepth(depth),
+				palette.WithBackground(bg, 3),
+			)
+			require.Positive(t, hasher.Len())
+
+			for i := 0; i < 200; i++ {
+				style := hasher.Style(strings.Repeat("x", i))
+				fg, ok := pal.Foreground(style)
+				require.True(t, ok)
+
+				require.GreaterOrEqual(t, color.Contrast(fg, bg), 3.0)
+				require.GreaterOrEqual(t, fg.OKLab().Chroma(), 0.05)
+				for _, reserved := range []color.Color{color.FgRed, color.FgHiRed} {
+					rgb, _ := pal.Foreground(reserved)
+					require.GreaterOrEqual(t, fg.OKLab().Distance(rgb.OKLab()), 0.12)
+				}
+			}
+		}
+	}
+
+	// The basic reds are reserved by default.
+	hasher := palette.NewHasher(palette.WithDepth(color.Depth16))
+	for i := 0; i < 100; i++ {
+		require.NotContains(t, []color.Style{color.FgRed, color.FgHiRed}, hasher.Style(fmt.Sprint(i)))
+	}
+
+	unreserved := palette.NewHasher(palette.WithDepth(color.Depth16), palette.WithReserved())
+	require.Greater(t, unreserved.Len(), hasher.Len())
+}
+
+func TestHash_Empty(t *testing.T) {
+	hasher := palette.NewHasher(palette.WithBackground(color.RGB{R: 1}, 21))
+	require.Zero(t, hasher.Len())
+	require.Equal(t, color.Nop, hasher.Style("x"))
+}
+
+func TestOptions(t *testing.T) {
+	custom := color.DefaultPalette()
+	custom[1] = color.RGB{R: 1}
+
+	opts := palette.DefaultOptions().With(palette.Options{
+		Depth:        color.Depth16,
+		Background:   color.RGB{R: 9},
+		MinContrast:  4.5,
+		Reserved:     []color.Style{},
+		Palette:      custom,
+		MinLightness: 0.2,
+		MaxLightness: 0.8,
+	})
+
+	require.Equal(t, color.Depth16, opts.Depth)
+	require.Equal(t, color.RGB{R: 9}, opts.Background)
+	require.InDelta(t, 4.5, opts.MinContrast, 0)
+	require.Empty(t, opts.Reserved)
+	require.Equal(t, custom, opts.Palette)
+	require.InDelta(t, 0.2, opts.MinLightness, 0)
+	require.InDelta(t, 0.8, opts.MaxLightness, 0)
+
+	opts = palette.DefaultOptions().With(palette.Options{}, palette.WithPalette(custom))
+	require.Equal(t, color.Depth256, opts.Depth)
+	require.Len(t, opts.Reserved, 2)
+	require.Equal(t, custom, opts.Palette)
+	require.Zero(t, opts.MinLightness)
+	require.InDelta(t, 1, opts.MaxLightness, 0)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package palette
+
+import (
+	"io"
+
+	"go.mway.dev/color"
+)
+
+// Options configure the colors chosen by a [Hasher] or [Generate].
+type Options struct {
+	// Depth is the color depth that chosen colors are restricted to.
+	// [color.DepthNone] is treated as unset.
+	Depth color.Depth
+	// Background is the terminal's background color. Colors that do not
+	// contrast with it are not chosen. A zero (black) background is treated
+	// as unset.
+	Background color.RGB
+	// MinContrast is the minimum WCAG contrast ratio, from 1 to 21, that
+	// chosen colors must have against Background.
+	MinContrast float64
+	// Reserved are styles whose foreground colors have a meaning of their
+	// own, such as the color of errors. Colors that are perceptually close to
+	// them are not chosen. A nil Reserved is treated as unset.
+	Reserved []color.Style
+	// Palette is used to resolve the RGB values of basic and 256-palette
+	// colors. A zero Palette is treated as unset.
+	Palette color.Palette
+	// MinLightness is the minimum OKLab lightness, from 0 to 1, of chosen
+	// colors.
+	MinLightness float64
+	// MaxLightness is the maximum OKLab lightness, from 0 to 1, of chosen
+	// colors. Zero is treated as unset.
+	MaxLightness float64
+}
+
+// DefaultOptions returns a new [Options] with default values, which assume a
+// 256-color terminal with a dark background and reserve red for errors.
+func DefaultOptions() Options {
+	return Options{
+		Depth:        color.Depth256,
+		MinContrast:  3,
+		Reserved:     []color.Style{color.FgRed, color.FgHiRed},
+		Palette:      color.DefaultPalette(),
+		MaxLightness: 1,
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	if o.Depth != color.DepthNone {
+		dst.Depth = o.Depth
+	}
+	if o.Background != (color.RGB{}) {
+		dst.Background = o.Background
+	}
+	if o.MinContrast > 0 {
+		dst.MinContrast = o.MinContrast
+	}
+	if o.Reserved != nil {
+		dst.Reserved = o.Reserved
+	}
+	if o.Palette != (color.Palette{}) {
+		dst.Palette = o.Palette
+	}
+	if o.MinLightness > 0 {
+		dst.MinLightness = o.MinLightness
+	}
+	if o.MaxLightness > 0 {
+		dst.MaxLightness = o.MaxLightness
+	}
+}
+
+// An Option configures the colors chosen by a [Hasher] or [Generate].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithDepth returns an [Option] that restricts chosen colors to depth.
+func WithDepth(depth color.Depth) Option {
+	return optionFunc(func(o *Options) {
+		o.Depth = depth
+	})
+}
+
+// WithDetectedDepth returns an [Option] that restricts chosen colors to the
+// depth supported by the terminal that w writes to (see [color.DepthFor]). No
+// colors are chosen if color is not enabled for w.
+func WithDetectedDepth(w io.Writer) Option {
+	return optionFunc(func(o *Options) {
+		o.Depth = color.DepthFor(w)
+	})
+}
+
+// WithLightness returns an [Option] that restricts chosen colors to those
+// with an OKLab lightness between lo and hi, from 0 (black) to 1 (white).
+func WithLightness(lo float64, hi float64) Option {
+	return optionFunc(func(o *Options) {
+		o.MinLightness = lo
+		o.MaxLightness = hi
+	})
+}
+
+// WithBackground returns an [Option] that sets the terminal's background
+// color, and the minimum contrast ratio chosen colors must have against it.
+func WithBackground(background color.RGB, minContrast float64) Option {
+	return optionFunc(func(o *Options) {
+		o.Background = background
+		o.MinContrast = minContrast
+	})
+}
+
+// WithReserved returns an [Option] that sets the styles whose foreground
+// colors are not chosen. Passing no styles reserves nothing.
+func WithReserved(styles ...color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.Reserved = append([]color.Style{}, styles...)
+	})
+}
+
+// WithPalette returns an [Option] that sets the palette used to resolve the
+// RGB values of basic and 256-palette colors.
+func WithPalette(palette color.Palette) Option {
+	return optionFunc(func(o *Options) {
+		o.Palette = palette
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPalette_Indexed(t *testing.T) {
+	p := DefaultPalette()
+
+	require.Equal(t, p[1], p.Indexed(1))
+	require.Equal(t, RGB{}, p.Indexed(16))
+	require.Equal(t, RGB{R: 255, G: 135}, p.Indexed(208))
+	require.Equal(t, RGB{R: 95, G: 135, B: 175}, p.Indexed(67))
+	require.Equal(t, RGB{R: 255, G: 255, B: 255}, p.Indexed(231))
+	require.Equal(t, RGB{R: 8, G: 8, B: 8}, p.Indexed(232))
+	require.Equal(t, RGB{R: 238, G: 238, B: 238}, p.Indexed(255))
+}
+
+func TestPalette_Colors(t *testing.T) {
+	var (
+		p     = DefaultPalette()
+		white = RGB{R: 255, G: 255, B: 255}
+	)
+
+	cases := map[string]struct {
+		style Style
+		fg    *RGB
+		bg    *RGB
+	}{
+		"nil":        {style: nil},
+		"nop":        {style: Nop},
+		"bold":       {style: Bold},
+		"fg":         {style: FgRed, fg: &p[1]},
+		"fg hi":      {style: FgHiBlue, fg: &p[12]},
+		"bg":         {style: BgGreen, bg: &p[2]},
+		"bg hi":      {style: BgHiWhite, bg: &white},
+		"fg256":      {style: Fg256(231), fg: &white},
+		"bg256":      {style: Bg256(9), bg: &p[9]},
+		"rgb":        {style: RGB{R: 1, G: 2, B: 3}.Fg(), fg: &RGB{R: 1, G: 2, B: 3}},
+		"combined":   {style: Combine(Bold, FgRed, Bg256(15)), fg: &p[1], bg: &white},
+		"override":   {style: Combine(FgRed, FgBlue), fg: &p[4]},
+		"reset":      {style: Combine(FgRed, BgRed, Reset)},
+		"default fg": {style: SGR("\x1b[31;41;39m"), bg: &p[1]},
+		"default bg": {style: SGR("\x1b[31;41;49m"), fg: &p[1]},
+		"truncated":  {style: SGR("\x1b[38;5m"), fg: nil},
+		"invalid":    {style: SGR("\x1b[38;2;1;2;300;1m"), fg: nil},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			fg, ok := p.Foreground(tt.style)
+			require.Equal(t, tt.fg != nil, ok)
+			if tt.fg != nil {
+				require.Equal(t, *tt.fg, fg)
+			}
+
+			bg, ok := p.Background(tt.style)
+			require.Equal(t, tt.bg != nil, ok)
+			if tt.bg != nil {
+				require.Equal(t, *tt.bg, bg)
+			}
+		})
+	}
+}
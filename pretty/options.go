@@ -0,0 +1,183 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package pretty
+
+import (
+	"go.mway.dev/color"
+)
+
+// A Theme is the set of styles used to print values.
+type Theme struct {
+	// Type is applied to type names.
+	Type color.Style
+	// Field is applied to struct field names.
+	Field color.Style
+	// String is applied to strings.
+	String color.Style
+	// Number is applied to integers, floats, and complex numbers.
+	Number color.Style
+	// Bool is applied to booleans.
+	Bool color.Style
+	// Nil is applied to nil values.
+	Nil color.Style
+	// Pointer is applied to addresses, e.g. of functions and channels.
+	Pointer color.Style
+	// Punct is applied to braces, colons, commas, and the like.
+	Punct color.Style
+	// Note is applied to annotations such as cycle and depth markers.
+	Note color.Style
+}
+
+// DefaultTheme returns the default [Theme].
+func DefaultTheme() Theme {
+	return Theme{
+		Type:    color.FgCyan,
+		Field:   color.FgBlue,
+		String:  color.FgGreen,
+		Number:  color.FgMagenta,
+		Bool:    color.FgYellow,
+		Nil:     color.FgHiBlack,
+		Pointer: color.FgHiBlack,
+		Punct:   color.Nop,
+		Note:    color.Combine(color.FgRed, color.Italic),
+	}
+}
+
+func (t Theme) merge(dst *Theme) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: t.Type, dst: &dst.Type},
+		{src: t.Field, dst: &dst.Field},
+		{src: t.String, dst: &dst.String},
+		{src: t.Number, dst: &dst.Number},
+		{src: t.Bool, dst: &dst.Bool},
+		{src: t.Nil, dst: &dst.Nil},
+		{src: t.Pointer, dst: &dst.Pointer},
+		{src: t.Punct, dst: &dst.Punct},
+		{src: t.Note, dst: &dst.Note},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Options configure a [Printer].
+type Options struct {
+	// Theme is the set of styles used to print values. Nil styles are left
+	// unchanged when options are merged.
+	Theme Theme
+	// Indent is repeated once per level of nesting.
+	Indent string
+	// MaxDepth, if positive, limits how deeply nested values are printed.
+	MaxDepth int
+	// ShowTypes controls whether type names are printed, as with the %#v
+	// verb.
+	ShowTypes bool
+	// DisableMethods controls whether the Error and String methods of values
+	// that implement error or [fmt.Stringer] are ignored.
+	DisableMethods bool
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Theme:  DefaultTheme(),
+		Indent: "  ",
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Theme.merge(&dst.Theme)
+	if len(o.Indent) > 0 {
+		dst.Indent = o.Indent
+	}
+	if o.MaxDepth > 0 {
+		dst.MaxDepth = o.MaxDepth
+	}
+	if o.ShowTypes {
+		dst.ShowTypes = o.ShowTypes
+	}
+	if o.DisableMethods {
+		dst.DisableMethods = o.DisableMethods
+	}
+}
+
+// An Option configures a [Printer].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithTheme returns an [Option] that sets the styles used to print values.
+// Nil styles in theme are ignored.
+func WithTheme(theme Theme) Option {
+	return optionFunc(func(o *Options) {
+		theme.merge(&o.Theme)
+	})
+}
+
+// WithIndent returns an [Option] that sets the string repeated once per level
+// of nesting.
+func WithIndent(indent string) Option {
+	return optionFunc(func(o *Options) {
+		o.Indent = indent
+	})
+}
+
+// WithMaxDepth returns an [Option] that limits how deeply nested values are
+// printed. A depth <= 0 is unlimited.
+func WithMaxDepth(depth int) Option {
+	return optionFunc(func(o *Options) {
+		o.MaxDepth = depth
+	})
+}
+
+// WithTypes returns an [Option] that controls whether type names are
+// printed.
+func WithTypes(show bool) Option {
+	return optionFunc(func(o *Options) {
+		o.ShowTypes = show
+	})
+}
+
+// WithMethods returns an [Option] that controls whether the Error and String
+// methods of values are used.
+func WithMethods(enabled bool) Option {
+	return optionFunc(func(o *Options) {
+		o.DisableMethods = !enabled
+	})
+}
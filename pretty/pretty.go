@@ -0,0 +1,365 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package pretty prints arbitrary Go values, with [color.Style]s applied, in
+// the manner of the %v and %#v verbs but with nested values indented.
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+// A Printer prints values.
+type Printer struct {
+	opts Options
+}
+
+// New creates a new [Printer] with the given options.
+func New(opts ...Option) Printer {
+	return Printer{
+		opts: DefaultOptions().With(opts...),
+	}
+}
+
+// Sprint is a convenience function that calls New(opts...).Sprint(v).
+func Sprint(v any, opts ...Option) string {
+	return New(opts...).Sprint(v)
+}
+
+// Fprint is a convenience function that calls New(opts...).Fprint(w, v).
+func Fprint(w io.Writer, v any, opts ...Option) (int, error) {
+	return New(opts...).Fprint(w, v)
+}
+
+// Println is a convenience function that prints v and a newline to stdout.
+func Println(v any, opts ...Option) {
+	fmt.Fprintln(os.Stdout, Sprint(v, opts...)) //nolint:errcheck
+}
+
+// Sprint returns v printed as a string.
+func (p Printer) Sprint(v any) string {
+	s := state{
+		opts: p.opts,
+		path: make(map[visit]struct{}),
+	}
+	s.print(reflect.ValueOf(v), 0)
+	return s.buf.String()
+}
+
+// Fprint prints v to w.
+func (p Printer) Fprint(w io.Writer, v any) (int, error) {
+	return io.WriteString(w, p.Sprint(v))
+}
+
+// A visit identifies a reference that is being printed, in order to detect
+// cycles.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type state struct {
+	opts Options
+	buf  strings.Builder
+	path map[visit]struct{}
+}
+
+func (s *state) print(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		s.write(s.opts.Theme.Nil, "nil")
+		return
+	}
+
+	if s.method(v) {
+		return
+	}
+
+	theme := s.opts.Theme
+	switch v.Kind() {
+	case reflect.Bool:
+		s.scalar(v, theme.Bool, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.scalar(v, theme.Number, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		s.scalar(v, theme.Number, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		s.scalar(v, theme.Number, strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		s.scalar(v, theme.Number, fmt.Sprint(v.Complex()))
+	case reflect.String:
+		s.scalar(v, theme.String, strconv.Quote(v.String()))
+	case reflect.Interface:
+		if v.IsNil() {
+			s.typedNil(v)
+			return
+		}
+		s.print(v.Elem(), depth)
+	case reflect.Pointer:
+		s.pointer(v, depth)
+	case reflect.Struct:
+		s.structure(v, depth)
+	case reflect.Map:
+		s.mapping(v, depth)
+	case reflect.Slice, reflect.Array:
+		s.list(v, depth)
+	default:
+		s.opaque(v)
+	}
+}
+
+// method prints v using its Error or String method, if any, and reports
+// whether it did so.
+func (s *state) method(v reflect.Value) (ok bool) {
+	if s.opts.DisableMethods || !v.CanInterface() {
+		return false
+	}
+	if k := v.Kind(); (k == reflect.Pointer || k == reflect.Interface) && v.IsNil() {
+		return false
+	}
+
+	var str string
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	switch x := v.Interface().(type) {
+	case error:
+		str = x.Error()
+	case fmt.Stringer:
+		str = x.String()
+	default:
+		return false
+	}
+
+	if s.opts.ShowTypes {
+		s.write(s.opts.Theme.Type, v.Type().String())
+		s.write(s.opts.Theme.Punct, "(")
+		s.write(s.opts.Theme.String, strconv.Quote(str))
+		s.write(s.opts.Theme.Punct, ")")
+	} else {
+		s.write(s.opts.Theme.String, str)
+	}
+
+	return true
+}
+
+// scalar prints str, which represents v, with style. If types are shown and
+// v's type is named, str is wrapped in a conversion to that type.
+func (s *state) scalar(v reflect.Value, style color.Style, str string) {
+	if !s.opts.ShowTypes || len(v.Type().PkgPath()) == 0 {
+		s.write(style, str)
+		return
+	}
+
+	s.write(s.opts.Theme.Type, v.Type().String())
+	s.write(s.opts.Theme.Punct, "(")
+	s.write(style, str)
+	s.write(s.opts.Theme.Punct, ")")
+}
+
+func (s *state) typedNil(v reflect.Value) {
+	if !s.opts.ShowTypes || v.Kind() == reflect.Interface {
+		s.write(s.opts.Theme.Nil, "nil")
+		return
+	}
+
+	s.write(s.opts.Theme.Punct, "(")
+	s.write(s.opts.Theme.Type, v.Type().String())
+	s.write(s.opts.Theme.Punct, ")(")
+	s.write(s.opts.Theme.Nil, "nil")
+	s.write(s.opts.Theme.Punct, ")")
+}
+
+func (s *state) pointer(v reflect.Value, depth int) {
+	if v.IsNil() {
+		s.typedNil(v)
+		return
+	}
+
+	if !s.enter(v) {
+		return
+	}
+	defer s.leave(v)
+
+	s.write(s.opts.Theme.Punct, "&")
+	s.print(v.Elem(), depth)
+}
+
+func (s *state) structure(v reflect.Value, depth int) {
+	typ := v.Type()
+	if s.opts.ShowTypes {
+		s.write(s.opts.Theme.Type, typ.String())
+	}
+
+	s.composite(depth, "{", "}", v.NumField(), func(i int) {
+		s.write(s.opts.Theme.Field, typ.Field(i).Name)
+		s.write(s.opts.Theme.Punct, ":")
+		s.buf.WriteByte(' ')
+		s.print(v.Field(i), depth+1)
+	})
+}
+
+func (s *state) mapping(v reflect.Value, depth int) {
+	if v.IsNil() {
+		s.typedNil(v)
+		return
+	}
+	if !s.enter(v) {
+		return
+	}
+	defer s.leave(v)
+
+	if s.opts.ShowTypes {
+		s.write(s.opts.Theme.Type, v.Type().String())
+	}
+
+	keys := v.MapKeys()
+	sortKeys(keys)
+
+	s.composite(depth, "{", "}", len(keys), func(i int) {
+		s.print(keys[i], depth+1)
+		s.write(s.opts.Theme.Punct, ":")
+		s.buf.WriteByte(' ')
+		s.print(v.MapIndex(keys[i]), depth+1)
+	})
+}
+
+func (s *state) list(v reflect.Value, depth int) {
+	if v.Kind() == reflect.Slice {
+		if v.IsNil() {
+			s.typedNil(v)
+			return
+		}
+		if v.Len() > 0 {
+			if !s.enter(v) {
+				return
+			}
+			defer s.leave(v)
+		}
+	}
+
+	open, closer := "[", "]"
+	if s.opts.ShowTypes {
+		s.write(s.opts.Theme.Type, v.Type().String())
+		open, closer = "{", "}"
+	}
+
+	s.composite(depth, open, closer, v.Len(), func(i int) {
+		s.print(v.Index(i), depth+1)
+	})
+}
+
+// composite prints n elements, each on its own line, between open and
+// closer.
+func (s *state) composite(depth int, open string, closer string, n int, elem func(int)) {
+	switch {
+	case n == 0:
+		s.write(s.opts.Theme.Punct, open+closer)
+		return
+	case s.opts.MaxDepth > 0 && depth >= s.opts.MaxDepth:
+		s.write(s.opts.Theme.Punct, open)
+		s.write(s.opts.Theme.Note, "…")
+		s.write(s.opts.Theme.Punct, closer)
+		return
+	}
+
+	s.write(s.opts.Theme.Punct, open)
+	for i := 0; i < n; i++ {
+		s.newline(depth + 1)
+		elem(i)
+		s.write(s.opts.Theme.Punct, ",")
+	}
+	s.newline(depth)
+	s.write(s.opts.Theme.Punct, closer)
+}
+
+// opaque prints a channel, function, or unsafe pointer.
+func (s *state) opaque(v reflect.Value) {
+	if v.IsNil() {
+		s.typedNil(v)
+		return
+	}
+
+	s.write(s.opts.Theme.Punct, "(")
+	s.write(s.opts.Theme.Type, v.Type().String())
+	s.write(s.opts.Theme.Punct, ")(")
+	s.write(s.opts.Theme.Pointer, fmt.Sprintf("%#x", v.Pointer()))
+	s.write(s.opts.Theme.Punct, ")")
+}
+
+// enter marks v as being printed. If v is already being printed, it prints a
+// cycle marker and returns false.
+func (s *state) enter(v reflect.Value) bool {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if _, ok := s.path[key]; ok {
+		s.write(s.opts.Theme.Note, "<cycle>")
+		return false
+	}
+	s.path[key] = struct{}{}
+	return true
+}
+
+func (s *state) leave(v reflect.Value) {
+	delete(s.path, visit{ptr: v.Pointer(), typ: v.Type()})
+}
+
+func (s *state) newline(depth int) {
+	s.buf.WriteByte('\n')
+	s.buf.WriteString(strings.Repeat(s.opts.Indent, depth))
+}
+
+func (s *state) write(style color.Style, str string) {
+	s.buf.WriteString(style.Wrap(str))
+}
+
+// sortKeys sorts map keys so that output is deterministic.
+func sortKeys(keys []reflect.Value) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.Kind() != b.Kind() {
+			return a.Kind() < b.Kind()
+		}
+
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		default:
+			return fmt.Sprint(a) < fmt.Sprint(b)
+		}
+	})
+}
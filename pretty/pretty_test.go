@@ -0,0 +1,224 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package pretty_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/pretty"
+)
+
+type (
+	ID    int
+	inner struct {
+		Tags []string
+		m    map[string]int
+	}
+	outer struct {
+		Name   string
+		ID     ID
+		Inner  *inner
+		Any    any
+		Err    error
+		Nested [2]bool
+	}
+	node struct {
+		Value int
+		Next  *node
+	}
+)
+
+func lines(strs ...string) string {
+	return strings.Join(strs, "\n")
+}
+
+func TestSprint(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	v := outer{
+		Name: "x",
+		ID:   7,
+		Inner: &inner{
+			Tags: []string{"a", "b"},
+			m:    map[string]int{"z": 1, "y": 2},
+		},
+		Any:    1.5,
+		Err:    errors.New("boom"),
+		Nested: [2]bool{true, false},
+	}
+
+	require.Equal(t, lines(
+		`{`,
+		`  Name: "x",`,
+		`  ID: 7,`,
+		`  Inner: &{`,
+		`    Tags: [`,
+		`      "a",`,
+		`      "b",`,
+		`    ],`,
+		`    m: {`,
+		`      "y": 2,`,
+		`      "z": 1,`,
+		`    },`,
+		`  },`,
+		`  Any: 1.5,`,
+		`  Err: boom,`,
+		`  Nested: [`,
+		`    true,`,
+		`    false,`,
+		`  ],`,
+		`}`,
+	), pretty.Sprint(v))
+
+	require.Equal(t, lines(
+		`pretty_test.outer{`,
+		`  Name: "x",`,
+		`  ID: pretty_test.ID(7),`,
+		`  Inner: (*pretty_test.inner)(nil),`,
+		`  Any: nil,`,
+		`  Err: nil,`,
+		`  Nested: [2]bool{`,
+		`    false,`,
+		`    false,`,
+		`  },`,
+		`}`,
+	), pretty.Sprint(outer{Name: "x", ID: 7}, pretty.WithTypes(true)))
+}
+
+func TestSprint_Scalars(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	cases := []struct {
+		give any
+		want string
+	}{
+		{give: nil, want: "nil"},
+		{give: uint8(3), want: "3"},
+		{give: float32(0.1), want: "0.1"},
+		{give: 1 + 2i, want: "(1+2i)"},
+		{give: "a\"b", want: `"a\"b"`},
+		{give: []int{}, want: "[]"},
+		{give: map[int]bool(nil), want: "nil"},
+		{give: (chan int)(nil), want: "nil"},
+		{give: time.Second, want: "1s"},
+	}
+
+	for _, tt := range cases {
+		require.Equal(t, tt.want, pretty.Sprint(tt.give))
+	}
+
+	require.Equal(t, "1000000000", pretty.Sprint(time.Second, pretty.WithMethods(false)))
+	require.Equal(
+		t,
+		`time.Duration("1s")`,
+		pretty.Sprint(time.Second, pretty.WithTypes(true)),
+	)
+	require.True(t, strings.HasPrefix(pretty.Sprint(make(chan int)), "(chan int)(0x"))
+}
+
+func TestSprint_Cycles(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	n := &node{Value: 1}
+	n.Next = &node{Value: 2, Next: n}
+
+	require.Equal(t, lines(
+		`&{`,
+		`  Value: 1,`,
+		`  Next: &{`,
+		`    Value: 2,`,
+		`    Next: <cycle>,`,
+		`  },`,
+		`}`,
+	), pretty.Sprint(n))
+
+	m := map[string]any{}
+	m["self"] = m
+	require.Equal(t, lines(`{`, `  "self": <cycle>,`, `}`), pretty.Sprint(m))
+
+	// Shared (but acyclic) references are printed in full.
+	shared := &node{Value: 3}
+	require.Equal(t, lines(
+		`[`,
+		`  &{`,
+		`    Value: 3,`,
+		`    Next: nil,`,
+		`  },`,
+		`  &{`,
+		`    Value: 3,`,
+		`    Next: nil,`,
+		`  },`,
+		`]`,
+	), pretty.Sprint([]*node{shared, shared}))
+}
+
+func TestSprint_MaxDepth(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	require.Equal(t, lines(
+		`[`,
+		`  […],`,
+		`]`,
+	), pretty.Sprint([][]int{{1}}, pretty.WithMaxDepth(1)))
+}
+
+func TestSprint_Theme(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		theme = pretty.DefaultTheme()
+		buf   bytes.Buffer
+	)
+
+	n, err := pretty.Fprint(&buf, map[string]bool{"k": true}, pretty.WithIndent("\t"))
+	require.NoError(t, err)
+	require.Equal(t, buf.Len(), n)
+	require.Equal(
+		t,
+		theme.Punct.Wrap("{")+"\n\t"+theme.String.Wrap(`"k"`)+theme.Punct.Wrap(":")+" "+
+			theme.Bool.Wrap("true")+theme.Punct.Wrap(",")+"\n"+theme.Punct.Wrap("}"),
+		buf.String(),
+	)
+}
+
+func TestOptions(t *testing.T) {
+	opts := pretty.DefaultOptions().With(pretty.Options{
+		Theme:          pretty.Theme{Field: color.FgRed},
+		Indent:         "\t",
+		MaxDepth:       3,
+		ShowTypes:      true,
+		DisableMethods: true,
+	})
+
+	require.Equal(t, color.FgRed, opts.Theme.Field)
+	require.Equal(t, color.FgCyan, opts.Theme.Type)
+	require.Equal(t, "\t", opts.Indent)
+	require.Equal(t, 3, opts.MaxDepth)
+	require.True(t, opts.ShowTypes)
+	require.True(t, opts.DisableMethods)
+}
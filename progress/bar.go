@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Bar displays progress towards a known total. It is safe for concurrent
+// use.
+type Bar struct {
+	mu      sync.Mutex
+	display display
+	total   int64
+	current int64
+	done    bool
+}
+
+var _ io.Writer = (*Bar)(nil)
+
+// NewBar creates a new [Bar] that writes to w and completes at total. A total
+// <= 0 displays only the count, rate, and elapsed time.
+func NewBar(w io.Writer, total int64, opts ...Option) *Bar {
+	return &Bar{
+		display: newDisplay(w, DefaultOptions().With(opts...)),
+		total:   total,
+	}
+}
+
+// Add adds n to b's current count, redrawing if necessary.
+func (b *Bar) Add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current += n
+	b.update()
+}
+
+// Set sets b's current count, redrawing if necessary.
+func (b *Bar) Set(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current = n
+	b.update()
+}
+
+// Write adds len(p) to b's current count, allowing b to be used with e.g.
+// [io.TeeReader] or [io.MultiWriter]. It never returns an error.
+func (b *Bar) Write(p []byte) (int, error) {
+	b.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// Finish draws b's final state and ends its line. Subsequent calls have no
+// effect.
+func (b *Bar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.done {
+		return
+	}
+	b.done = true
+	b.display.finish(b.render())
+}
+
+// String returns b's current rendering.
+func (b *Bar) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.render()
+}
+
+func (b *Bar) update() {
+	if b.done {
+		return
+	}
+	b.display.update(b.total > 0 && b.current >= b.total, b.render)
+}
+
+func (b *Bar) render() string {
+	var (
+		d     = &b.display
+		opts  = d.opts
+		parts = make([]string, 0, 6)
+	)
+
+	if len(opts.Label) > 0 {
+		parts = append(parts, d.label())
+	}
+
+	if b.total > 0 {
+		frac := min(max(float64(b.current)/float64(b.total), 0), 1)
+		if d.interactive {
+			parts = append(parts, b.bar(frac))
+		}
+		if opts.ShowPercent {
+			// Pad the percentage when redrawing so that the line does not
+			// shift as it grows.
+			format := "%.0f%%"
+			if d.interactive {
+				format = "%3.0f%%"
+			}
+			parts = append(parts, d.info(fmt.Sprintf(format, frac*100)))
+		}
+	}
+
+	if opts.ShowCount {
+		parts = append(parts, d.info(b.count()))
+	}
+	if opts.ShowRate {
+		parts = append(parts, d.rate(b.current))
+	}
+	if opts.ShowETA && b.total > 0 {
+		parts = append(parts, d.info("eta "+b.eta()))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (b *Bar) bar(frac float64) string {
+	var (
+		opts   = b.display.opts
+		filled = int(frac * float64(opts.Width))
+	)
+
+	return b.display.style(opts.FilledStyle).Wrap(strings.Repeat(opts.Filled, filled)) +
+		b.display.style(opts.EmptyStyle).Wrap(strings.Repeat(opts.Empty, opts.Width-filled))
+}
+
+func (b *Bar) count() string {
+	units := b.display.opts.Units
+	if b.total <= 0 {
+		return formatCount(float64(b.current), units)
+	}
+	return formatCount(float64(b.current), units) + "/" + formatCount(float64(b.total), units)
+}
+
+func (b *Bar) eta() string {
+	switch {
+	case b.current >= b.total:
+		return formatDuration(0)
+	case b.current <= 0:
+		return "--"
+	}
+
+	elapsed := b.display.elapsed()
+	remaining := time.Duration(float64(elapsed) * float64(b.total-b.current) / float64(b.current))
+	return formatDuration(remaining)
+}
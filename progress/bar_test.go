@@ -0,0 +1,250 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/cursor"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestBar_Interactive(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		buf   bytes.Buffer
+		bar   = NewBar(
+			&buf,
+			100,
+			WithMode(ModeInteractive),
+			WithNowFunc(clock.Now),
+			WithWidth(10),
+			WithLabel("copy", color.Bold),
+			WithRate(true),
+		)
+	)
+
+	clock.Add(time.Second)
+	bar.Add(25)
+	require.Equal(
+		t,
+		"\r"+color.Bold.Wrap("copy")+" "+
+			color.FgGreen.Wrap("██")+color.FgHiBlack.Wrap("░░░░░░░░")+
+			"  25% 25/100 25/s eta 3s"+cursor.EraseLineRight.String(),
+		buf.String(),
+	)
+
+	// Redraws are throttled.
+	buf.Reset()
+	bar.Add(25)
+	require.Zero(t, buf.Len())
+
+	// Completion always redraws.
+	clock.Add(time.Second)
+	bar.Write(make([]byte, 50)) //nolint:errcheck
+	require.Contains(t, buf.String(), " 100% 100/100 50/s eta 0s")
+
+	buf.Reset()
+	bar.Finish()
+	bar.Finish()
+	require.Equal(t, "\r"+bar.String()+cursor.EraseLineRight.String()+"\n", buf.String())
+
+	buf.Reset()
+	bar.Add(1)
+	require.Zero(t, buf.Len())
+}
+
+func TestBar_Plain(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		buf   bytes.Buffer
+		bar   = NewBar(
+			&buf,
+			2048,
+			WithNowFunc(clock.Now),
+			WithLabel("download", color.Bold),
+			WithUnits(UnitsBytes),
+			WithIntervals(time.Millisecond, time.Minute),
+			WithETA(false),
+			WithRate(true),
+		)
+	)
+
+	clock.Add(time.Second)
+	bar.Set(1024)
+	clock.Add(time.Second)
+	bar.Set(1536)
+	clock.Add(time.Minute)
+	bar.Set(2000)
+	bar.Finish()
+
+	require.Equal(t, strings.Join([]string{
+		"download 50% 1.0KiB/2.0KiB 1.0KiB/s",
+		"download 98% 2.0KiB/2.0KiB 32B/s",
+		"download 98% 2.0KiB/2.0KiB 32B/s",
+	}, "\n")+"\n", buf.String())
+}
+
+func TestBar_Indeterminate(t *testing.T) {
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		bar   = NewBar(io.Discard, 0, WithNowFunc(clock.Now), WithRate(true))
+	)
+
+	require.Equal(t, "0 --/s", bar.String())
+
+	clock.Add(2 * time.Second)
+	bar.Add(5)
+	require.Equal(t, "5 2.5/s", bar.String())
+}
+
+func TestBar_ETA(t *testing.T) {
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		bar   = NewBar(io.Discard, 10, WithNowFunc(clock.Now), WithMode(ModePlain))
+	)
+
+	require.Equal(t, "0% 0/10 eta --", bar.String())
+}
+
+func TestFormatCount(t *testing.T) {
+	cases := []struct {
+		give  float64
+		units Units
+		want  string
+	}{
+		{give: 12, want: "12"},
+		{give: 1.25, want: "1.2"},
+		{give: 1000, units: UnitsBytes, want: "1000B"},
+		{give: 1536, units: UnitsBytes, want: "1.5KiB"},
+		{give: 3 << 30, units: UnitsBytes, want: "3.0GiB"},
+	}
+
+	for _, tt := range cases {
+		require.Equal(t, tt.want, formatCount(tt.give, tt.units))
+	}
+}
+
+func TestOptions(t *testing.T) {
+	opts := DefaultOptions().With(Options{
+		Label:           "x",
+		LabelStyle:      color.FgRed,
+		FilledStyle:     color.FgBlue,
+		EmptyStyle:      color.FgWhite,
+		SpinnerStyle:    color.FgYellow,
+		InfoStyle:       color.Faint,
+		Filled:          "#",
+		Empty:           "-",
+		Frames:          []string{"a", "b"},
+		Width:           5,
+		ShowRate:        true,
+		Units:           UnitsBytes,
+		Mode:            ModePlain,
+		RefreshInterval: time.Second,
+		LogInterval:     time.Hour,
+		NowFunc:         time.Now,
+	}, WithBarChars("=", " "), WithSpinner(color.FgGreen))
+
+	require.Equal(t, "x", opts.Label)
+	require.Equal(t, color.FgRed, opts.LabelStyle)
+	require.Equal(t, color.FgBlue, opts.FilledStyle)
+	require.Equal(t, color.FgWhite, opts.EmptyStyle)
+	require.Equal(t, color.FgGreen, opts.SpinnerStyle)
+	require.Equal(t, color.Faint, opts.InfoStyle)
+	require.Equal(t, "=", opts.Filled)
+	require.Equal(t, " ", opts.Empty)
+	require.Equal(t, []string{"a", "b"}, opts.Frames)
+	require.Equal(t, 5, opts.Width)
+	require.True(t, opts.ShowPercent)
+	require.True(t, opts.ShowRate)
+	require.Equal(t, UnitsBytes, opts.Units)
+	require.Equal(t, ModePlain, opts.Mode)
+	require.Equal(t, time.Second, opts.RefreshInterval)
+	require.Equal(t, time.Hour, opts.LogInterval)
+}
+
+func TestBar_Auto(t *testing.T) {
+	// Color being enabled does not make a non-terminal writer interactive.
+	colortest.ForceColor(t, true)
+
+	var (
+		buf bytes.Buffer
+		bar = NewBar(&buf, 10, WithLabel("copy", color.FgRed))
+	)
+	require.False(t, bar.display.interactive)
+	require.False(t, bar.display.styled)
+
+	bar = NewBar(&buf, 10, WithMode(ModeInteractive))
+	require.True(t, bar.display.interactive)
+	require.True(t, bar.display.styled)
+
+	colortest.ForceColor(t, false)
+	bar = NewBar(&buf, 10, WithMode(ModeInteractive))
+	require.True(t, bar.display.interactive)
+	require.False(t, bar.display.styled)
+}
+
+func TestWithIntervals_NonPositive(t *testing.T) {
+	var (
+		defaults = DefaultOptions()
+		opts     = defaults.With(WithIntervals(0, -time.Second))
+	)
+	require.Equal(t, defaults.RefreshInterval, opts.RefreshInterval)
+	require.Equal(t, defaults.LogInterval, opts.LogInterval)
+}
+
+func TestOptions_Invalid(t *testing.T) {
+	var (
+		defaults = DefaultOptions()
+		opts     = defaults.With(WithWidth(-1), WithWidth(0), WithNowFunc(nil))
+	)
+	require.Equal(t, defaults.Width, opts.Width)
+	require.NotNil(t, opts.NowFunc)
+
+	var buf bytes.Buffer
+	require.NotPanics(t, func() {
+		bar := NewBar(&buf, 10, WithMode(ModeInteractive), WithWidth(-1), WithNowFunc(nil))
+		bar.Add(5)
+		bar.Finish()
+	})
+}
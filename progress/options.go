@@ -0,0 +1,320 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package progress
+
+import (
+	"time"
+
+	"go.mway.dev/color"
+)
+
+// Mode controls how progress is displayed.
+type Mode int
+
+// Modes.
+const (
+	// ModeAuto redraws in place if color is enabled for the destination
+	// writer (see [color.EnabledFor]) and the writer is a terminal (see
+	// [color.IsTerminal]), and otherwise behaves as ModePlain.
+	ModeAuto Mode = iota
+	// ModeInteractive redraws progress in place using carriage returns.
+	ModeInteractive
+	// ModePlain periodically writes unstyled log lines.
+	ModePlain
+)
+
+// Units controls how counts and rates are formatted.
+type Units int
+
+// Units.
+const (
+	// UnitsNone formats counts as plain numbers.
+	UnitsNone Units = iota
+	// UnitsBytes formats counts as binary byte sizes, e.g. "1.5MiB".
+	UnitsBytes
+)
+
+// Options configure a [Bar] or [Spinner].
+type Options struct {
+	// Label is displayed before the progress indicator.
+	Label string
+	// LabelStyle is the style of the label.
+	LabelStyle color.Style
+	// FilledStyle is the style of the completed portion of a bar.
+	FilledStyle color.Style
+	// EmptyStyle is the style of the remaining portion of a bar.
+	EmptyStyle color.Style
+	// SpinnerStyle is the style of a spinner's frames.
+	SpinnerStyle color.Style
+	// InfoStyle is the style of the percentage, count, rate, and ETA.
+	InfoStyle color.Style
+	// Filled is the string drawn for each completed cell of a bar.
+	Filled string
+	// Empty is the string drawn for each remaining cell of a bar.
+	Empty string
+	// Frames are the frames of a spinner.
+	Frames []string
+	// Width is the number of cells in a bar.
+	Width int
+	// ShowPercent controls whether a bar displays its percentage.
+	ShowPercent bool
+	// ShowCount controls whether the current count and total are displayed.
+	ShowCount bool
+	// ShowRate controls whether throughput is displayed.
+	ShowRate bool
+	// ShowETA controls whether a bar displays its estimated time remaining.
+	ShowETA bool
+	// Units controls how counts and rates are formatted.
+	Units Units
+	// Mode controls how progress is displayed.
+	Mode Mode
+	// RefreshInterval is the minimum time between in-place redraws.
+	RefreshInterval time.Duration
+	// LogInterval is the minimum time between plain log lines.
+	LogInterval time.Duration
+	// NowFunc returns the current time.
+	NowFunc func() time.Time
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		LabelStyle:      color.Bold,
+		FilledStyle:     color.FgGreen,
+		EmptyStyle:      color.FgHiBlack,
+		SpinnerStyle:    color.FgCyan,
+		InfoStyle:       color.Nop,
+		Filled:          "█",
+		Empty:           "░",
+		Frames:          []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		Width:           30,
+		ShowPercent:     true,
+		ShowCount:       true,
+		ShowETA:         true,
+		RefreshInterval: 100 * time.Millisecond,
+		LogInterval:     5 * time.Second,
+		NowFunc:         time.Now,
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) { //nolint:gocyclo
+	if len(o.Label) > 0 {
+		dst.Label = o.Label
+	}
+	if o.LabelStyle != nil {
+		dst.LabelStyle = o.LabelStyle
+	}
+	if o.FilledStyle != nil {
+		dst.FilledStyle = o.FilledStyle
+	}
+	if o.EmptyStyle != nil {
+		dst.EmptyStyle = o.EmptyStyle
+	}
+	if o.SpinnerStyle != nil {
+		dst.SpinnerStyle = o.SpinnerStyle
+	}
+	if o.InfoStyle != nil {
+		dst.InfoStyle = o.InfoStyle
+	}
+	if len(o.Filled) > 0 {
+		dst.Filled = o.Filled
+	}
+	if len(o.Empty) > 0 {
+		dst.Empty = o.Empty
+	}
+	if len(o.Frames) > 0 {
+		dst.Frames = o.Frames
+	}
+	if o.Width > 0 {
+		dst.Width = o.Width
+	}
+	if o.ShowPercent {
+		dst.ShowPercent = o.ShowPercent
+	}
+	if o.ShowCount {
+		dst.ShowCount = o.ShowCount
+	}
+	if o.ShowRate {
+		dst.ShowRate = o.ShowRate
+	}
+	if o.ShowETA {
+		dst.ShowETA = o.ShowETA
+	}
+	if o.Units != UnitsNone {
+		dst.Units = o.Units
+	}
+	if o.Mode != ModeAuto {
+		dst.Mode = o.Mode
+	}
+	if o.RefreshInterval > 0 {
+		dst.RefreshInterval = o.RefreshInterval
+	}
+	if o.LogInterval > 0 {
+		dst.LogInterval = o.LogInterval
+	}
+	if o.NowFunc != nil {
+		dst.NowFunc = o.NowFunc
+	}
+}
+
+// An Option configures a [Bar] or [Spinner].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithLabel returns an [Option] that sets the label and its style.
+func WithLabel(label string, style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.Label = label
+		o.LabelStyle = style
+	})
+}
+
+// WithBarStyles returns an [Option] that sets the styles of the completed and
+// remaining portions of a bar.
+func WithBarStyles(filled color.Style, empty color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.FilledStyle = filled
+		o.EmptyStyle = empty
+	})
+}
+
+// WithBarChars returns an [Option] that sets the strings drawn for completed
+// and remaining cells of a bar.
+func WithBarChars(filled string, empty string) Option {
+	return optionFunc(func(o *Options) {
+		o.Filled = filled
+		o.Empty = empty
+	})
+}
+
+// WithSpinner returns an [Option] that sets a spinner's frames and style.
+func WithSpinner(style color.Style, frames ...string) Option {
+	return optionFunc(func(o *Options) {
+		o.SpinnerStyle = style
+		if len(frames) > 0 {
+			o.Frames = frames
+		}
+	})
+}
+
+// WithInfoStyle returns an [Option] that sets the style of the percentage,
+// count, rate, and ETA.
+func WithInfoStyle(style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.InfoStyle = style
+	})
+}
+
+// WithWidth returns an [Option] that sets the number of cells in a bar.
+// Non-positive widths are ignored.
+func WithWidth(width int) Option {
+	return optionFunc(func(o *Options) {
+		if width > 0 {
+			o.Width = width
+		}
+	})
+}
+
+// WithPercent returns an [Option] that controls whether a bar displays its
+// percentage.
+func WithPercent(show bool) Option {
+	return optionFunc(func(o *Options) {
+		o.ShowPercent = show
+	})
+}
+
+// WithCount returns an [Option] that controls whether the current count and
+// total are displayed.
+func WithCount(show bool) Option {
+	return optionFunc(func(o *Options) {
+		o.ShowCount = show
+	})
+}
+
+// WithRate returns an [Option] that controls whether throughput is
+// displayed.
+func WithRate(show bool) Option {
+	return optionFunc(func(o *Options) {
+		o.ShowRate = show
+	})
+}
+
+// WithETA returns an [Option] that controls whether a bar displays its
+// estimated time remaining.
+func WithETA(show bool) Option {
+	return optionFunc(func(o *Options) {
+		o.ShowETA = show
+	})
+}
+
+// WithUnits returns an [Option] that controls how counts and rates are
+// formatted.
+func WithUnits(units Units) Option {
+	return optionFunc(func(o *Options) {
+		o.Units = units
+	})
+}
+
+// WithMode returns an [Option] that controls how progress is displayed.
+func WithMode(mode Mode) Option {
+	return optionFunc(func(o *Options) {
+		o.Mode = mode
+	})
+}
+
+// WithIntervals returns an [Option] that sets the minimum time between
+// in-place redraws and between plain log lines. Non-positive intervals are
+// ignored.
+func WithIntervals(refresh time.Duration, log time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		if refresh > 0 {
+			o.RefreshInterval = refresh
+		}
+		if log > 0 {
+			o.LogInterval = log
+		}
+	})
+}
+
+// WithNowFunc returns an [Option] that sets the function used to get the
+// current time. A nil function is ignored.
+func WithNowFunc(fn func() time.Time) Option {
+	return optionFunc(func(o *Options) {
+		if fn != nil {
+			o.NowFunc = fn
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package progress provides progress bars and spinners that render using
+// [color.Style]s.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"go.mway.dev/color"
+	"go.mway.dev/color/cursor"
+)
+
+// display writes progress lines to a writer, either redrawing in place or as
+// periodic log lines.
+type display struct {
+	w           io.Writer
+	opts        Options
+	interactive bool
+	styled      bool
+	start       time.Time
+	lastDraw    time.Time
+	lastLog     time.Time
+}
+
+func newDisplay(w io.Writer, opts Options) display {
+	interactive := opts.Mode == ModeInteractive ||
+		(opts.Mode == ModeAuto && color.IsTerminal(w) && color.EnabledFor(w))
+	return display{
+		w:           w,
+		opts:        opts,
+		interactive: interactive,
+		styled:      interactive && color.EnabledFor(w),
+		start:       opts.NowFunc(),
+	}
+}
+
+// update draws the line returned by render if enough time has passed since
+// the previous draw, or if force is true.
+func (d *display) update(force bool, render func() string) {
+	now := d.opts.NowFunc()
+
+	if d.interactive {
+		if !force && now.Sub(d.lastDraw) < d.opts.RefreshInterval {
+			return
+		}
+		d.lastDraw = now
+		io.WriteString(d.w, "\r"+render()+cursor.EraseLineRight.String()) //nolint:errcheck
+		return
+	}
+
+	if !force && now.Sub(d.lastLog) < d.opts.LogInterval {
+		return
+	}
+	d.lastLog = now
+	io.WriteString(d.w, render()+"\n") //nolint:errcheck
+}
+
+// finish draws the final line.
+func (d *display) finish(line string) {
+	if d.interactive {
+		line = "\r" + line + cursor.EraseLineRight.String()
+	}
+	io.WriteString(d.w, line+"\n") //nolint:errcheck
+}
+
+// style returns s if d redraws in place and color is enabled for its writer,
+// or [color.Nop] otherwise.
+func (d *display) style(s color.Style) color.Style {
+	if !d.styled || s == nil {
+		return color.Nop
+	}
+	return s
+}
+
+func (d *display) elapsed() time.Duration {
+	return d.opts.NowFunc().Sub(d.start)
+}
+
+func (d *display) label() string {
+	return d.style(d.opts.LabelStyle).Wrap(d.opts.Label)
+}
+
+func (d *display) info(str string) string {
+	return d.style(d.opts.InfoStyle).Wrap(str)
+}
+
+func (d *display) rate(count int64) string {
+	secs := d.elapsed().Seconds()
+	if secs <= 0 {
+		return d.info("--/s")
+	}
+	return d.info(formatCount(float64(count)/secs, d.opts.Units) + "/s")
+}
+
+func formatCount(n float64, units Units) string {
+	if units != UnitsBytes {
+		if n == math.Trunc(n) {
+			return strconv.FormatInt(int64(n), 10)
+		}
+		return strconv.FormatFloat(n, 'f', 1, 64)
+	}
+
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%.0fB", n)
+	}
+
+	exp := 0
+	for n >= unit && exp < 6 {
+		n /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", n, "KMGTPE"[exp-1])
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
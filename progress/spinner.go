@@ -0,0 +1,142 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package progress
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// A Spinner displays activity without a known total. It is safe for
+// concurrent use.
+type Spinner struct {
+	mu      sync.Mutex
+	display display
+	frame   int
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewSpinner creates a new [Spinner] that writes to w. The spinner is not
+// drawn until [Spinner.Start] is called.
+func NewSpinner(w io.Writer, opts ...Option) *Spinner {
+	return &Spinner{
+		display: newDisplay(w, DefaultOptions().With(opts...)),
+	}
+}
+
+// Start draws s and begins animating it. Calling Start on a running spinner
+// has no effect.
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	s.display.start = s.display.opts.NowFunc()
+	s.display.update(true, s.render)
+
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	go s.run(s.display.opts.RefreshInterval, s.stop, s.stopped)
+}
+
+// SetLabel changes s's label.
+func (s *Spinner) SetLabel(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.display.opts.Label = label
+}
+
+// Stop stops animating s and draws its final state, including the elapsed
+// time. Calling Stop on a spinner that is not running has no effect.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	if s.stop == nil {
+		s.mu.Unlock()
+		return
+	}
+	close(s.stop)
+	stopped := s.stopped
+	s.stop, s.stopped = nil, nil
+	s.mu.Unlock()
+
+	<-stopped
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line := formatDuration(s.display.elapsed())
+	if label := s.display.label(); len(label) > 0 {
+		line = label + " " + line
+	}
+	s.display.finish(line)
+}
+
+func (s *Spinner) run(
+	interval time.Duration,
+	stop <-chan struct{},
+	stopped chan<- struct{},
+) {
+	defer close(stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.tick()
+		}
+	}
+}
+
+func (s *Spinner) tick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.frame++
+	s.display.update(false, s.render)
+}
+
+func (s *Spinner) render() string {
+	var (
+		d     = &s.display
+		label = d.label()
+	)
+
+	if !d.interactive {
+		elapsed := d.info("(" + formatDuration(d.elapsed()) + ")")
+		if len(label) == 0 {
+			return elapsed
+		}
+		return label + " " + elapsed
+	}
+
+	frames := d.opts.Frames
+	return d.style(d.opts.SpinnerStyle).Wrap(frames[s.frame%len(frames)]) + " " + label
+}
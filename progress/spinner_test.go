@@ -0,0 +1,137 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package progress
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/cursor"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSpinner_Interactive(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		buf   bytes.Buffer
+		spin  = NewSpinner(
+			&buf,
+			WithMode(ModeInteractive),
+			WithNowFunc(clock.Now),
+			WithSpinner(color.FgCyan, "a", "b"),
+			WithLabel("work", color.Nop),
+		)
+	)
+
+	spin.display.update(true, spin.render)
+	clock.Add(time.Second)
+	spin.tick()
+	clock.Add(time.Second)
+	spin.tick()
+
+	require.Equal(
+		t,
+		"\r"+color.FgCyan.Wrap("a")+" work"+cursor.EraseLineRight.String()+
+			"\r"+color.FgCyan.Wrap("b")+" work"+cursor.EraseLineRight.String()+
+			"\r"+color.FgCyan.Wrap("a")+" work"+cursor.EraseLineRight.String(),
+		buf.String(),
+	)
+}
+
+func TestSpinner_Plain(t *testing.T) {
+	var (
+		clock = &fakeClock{now: time.Unix(0, 0)}
+		buf   bytes.Buffer
+		spin  = NewSpinner(
+			&buf,
+			WithMode(ModePlain),
+			WithNowFunc(clock.Now),
+			WithIntervals(time.Millisecond, 10*time.Second),
+		)
+	)
+
+	spin.display.update(true, spin.render)
+	clock.Add(time.Second)
+	spin.tick()
+	clock.Add(10 * time.Second)
+	spin.SetLabel("waiting")
+	spin.tick()
+
+	require.Equal(t, "(0s)\nwaiting (11s)\n", buf.String())
+}
+
+func TestSpinner_StartStop(t *testing.T) {
+	var (
+		buf  syncBuffer
+		spin = NewSpinner(
+			&buf,
+			WithMode(ModePlain),
+			WithLabel("run", color.Nop),
+			WithIntervals(time.Millisecond, time.Hour),
+		)
+	)
+
+	spin.Stop()
+	require.Empty(t, buf.String())
+
+	spin.Start()
+	spin.Start()
+	time.Sleep(5 * time.Millisecond)
+	spin.Stop()
+	spin.Stop()
+
+	require.Equal(t, "run (0s)\nrun 0s\n", buf.String())
+}
+
+func TestSpinner_ZeroInterval(t *testing.T) {
+	var (
+		buf  syncBuffer
+		spin = NewSpinner(&buf, WithMode(ModePlain), WithIntervals(0, 0))
+	)
+
+	require.NotPanics(t, func() {
+		spin.Start()
+		spin.Stop()
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// An RGB is a 24-bit sRGB color.
+type RGB struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+// Fg returns a [Style] that sets the foreground to c. It requires a terminal
+// that supports 24-bit color.
+func (c RGB) Fg() Style {
+	return SGR("\x1b[38;2;" + c.code() + "m")
+}
+
+// Bg returns a [Style] that sets the background to c. It requires a terminal
+// that supports 24-bit color.
+func (c RGB) Bg() Style {
+	return SGR("\x1b[48;2;" + c.code() + "m")
+}
+
+// String returns c in hexadecimal notation, e.g. "#ff8700".
+func (c RGB) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Luminance returns the relative luminance of c, from 0 (black) to 1 (white),
+// as defined by WCAG 2.
+func (c RGB) Luminance() float64 {
+	r, g, b := c.linear()
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// OKLab returns c in the OKLab color space.
+func (c RGB) OKLab() OKLab {
+	r, g, b := c.linear()
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return OKLab{
+		L: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		A: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		B: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+func (c RGB) code() string {
+	return strconv.Itoa(int(c.R)) + ";" +
+		strconv.Itoa(int(c.G)) + ";" +
+		strconv.Itoa(int(c.B))
+}
+
+func (c RGB) linear() (float64, float64, float64) {
+	return toLinear(c.R), toLinear(c.G), toLinear(c.B)
+}
+
+// An OKLab is a color in the perceptually uniform OKLab color space, in which
+// L is the perceived lightness (0 to 1), and A and B are the green-red and
+// blue-yellow axes. Euclidean distance between OKLab colors approximates their
+// perceived difference.
+type OKLab struct {
+	L float64
+	A float64
+	B float64
+}
+
+// LCH returns the [OKLab] color with the given lightness (0 to 1), chroma
+// (0 to roughly 0.37), and hue (in degrees).
+func LCH(lightness float64, chroma float64, hue float64) OKLab {
+	rad := hue * math.Pi / 180
+	return OKLab{
+		L: lightness,
+		A: chroma * math.Cos(rad),
+		B: chroma * math.Sin(rad),
+	}
+}
+
+// Chroma returns the colorfulness of c, where 0 is gray.
+func (c OKLab) Chroma() float64 {
+	return math.Hypot(c.A, c.B)
+}
+
+// Hue returns the hue of c in degrees, from 0 to 360.
+func (c OKLab) Hue() float64 {
+	hue := math.Atan2(c.B, c.A) * 180 / math.Pi
+	if hue < 0 {
+		hue += 360
+	}
+	return hue
+}
+
+// Distance returns the perceived difference between c and other.
+func (c OKLab) Distance(other OKLab) float64 {
+	return math.Sqrt(
+		(c.L-other.L)*(c.L-other.L) +
+			(c.A-other.A)*(c.A-other.A) +
+			(c.B-other.B)*(c.B-other.B),
+	)
+}
+
+// RGB returns c as an [RGB]. If c is outside of the sRGB gamut, its chroma is
+// reduced, preserving its lightness and hue, until it is representable.
+func (c OKLab) RGB() RGB {
+	c.L = min(max(c.L, 0), 1)
+	if r, g, b, ok := c.linear(); ok {
+		return RGB{R: fromLinear(r), G: fromLinear(g), B: fromLinear(b)}
+	}
+
+	var (
+		lo, hi = 0.0, 1.0
+		gray   = OKLab{L: c.L}
+	)
+
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if _, _, _, ok := gray.mix(c, mid).linear(); ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	r, g, b, _ := gray.mix(c, lo).linear()
+	return RGB{R: fromLinear(r), G: fromLinear(g), B: fromLinear(b)}
+}
+
+// mix returns the color t of the way from c to other.
+func (c OKLab) mix(other OKLab, t float64) OKLab {
+	return OKLab{
+		L: c.L + (other.L-c.L)*t,
+		A: c.A + (other.A-c.A)*t,
+		B: c.B + (other.B-c.B)*t,
+	}
+}
+
+// linear returns c as linear sRGB components, and whether they are all within
+// the sRGB gamut.
+func (c OKLab) linear() (float64, float64, float64, bool) {
+	l := c.L + 0.3963377774*c.A + 0.2158037573*c.B
+	m := c.L - 0.1055613458*c.A - 0.0638541728*c.B
+	s := c.L - 0.0894841775*c.A - 1.2914855480*c.B
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	r := 4.0767416621*l - 3.3077115913*m + 0.2309699292*s
+	g := -1.2684380046*l + 2.6097574011*m - 0.3413193965*s
+	b := -0.0041960863*l - 0.7034186147*m + 1.7076147010*s
+
+	const eps = 1e-9
+	ok := r >= -eps && r <= 1+eps &&
+		g >= -eps && g <= 1+eps &&
+		b >= -eps && b <= 1+eps
+	return r, g, b, ok
+}
+
+func toLinear(c uint8) float64 {
+	x := float64(c) / 255
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
+
+func fromLinear(x float64) uint8 {
+	x = min(max(x, 0), 1)
+	if x <= 0.0031308 {
+		x *= 12.92
+	} else {
+		x = 1.055*math.Pow(x, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(x * 255))
+}
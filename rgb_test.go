@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRGB(t *testing.T) {
+	require.Equal(t, "#ff8700", RGB{R: 255, G: 135}.String())
+	require.Equal(t, "#000000", RGB{}.String())
+
+	require.InDelta(t, 0, RGB{}.Luminance(), 1e-9)
+	require.InDelta(t, 1, RGB{R: 255, G: 255, B: 255}.Luminance(), 1e-9)
+	require.InDelta(t, 0.2126, RGB{R: 255}.Luminance(), 1e-9)
+}
+
+func TestOKLab(t *testing.T) {
+	white := RGB{R: 255, G: 255, B: 255}.OKLab()
+	require.InDelta(t, 1, white.L, 1e-3)
+	require.InDelta(t, 0, white.Chroma(), 1e-3)
+
+	red := RGB{R: 255}.OKLab()
+	require.InDelta(t, 0.628, red.L, 1e-3)
+	require.InDelta(t, 0.258, red.Chroma(), 1e-3)
+	require.InDelta(t, 29.2, red.Hue(), 0.1)
+
+	blue := RGB{B: 255}.OKLab()
+	require.InDelta(t, 264.1, blue.Hue(), 0.1)
+
+	require.InDelta(t, 0, red.Distance(red), 1e-9)
+	require.Greater(t, red.Distance(blue), red.Distance(RGB{R: 255, G: 64}.OKLab()))
+}
+
+func TestOKLab_RoundTrip(t *testing.T) {
+	for _, c := range []RGB{
+		{},
+		{R: 255, G: 255, B: 255},
+		{R: 255},
+		{G: 255},
+		{B: 255},
+		{R: 12, G: 200, B: 97},
+		{R: 128, G: 128, B: 128},
+	} {
+		require.Equal(t, c, c.OKLab().RGB(), c.String())
+	}
+}
+
+func TestLCH(t *testing.T) {
+	lab := LCH(0.7, 0.1, 120)
+	require.InDelta(t, 0.7, lab.L, 1e-9)
+	require.InDelta(t, 0.1, lab.Chroma(), 1e-9)
+	require.InDelta(t, 120, lab.Hue(), 1e-9)
+
+	// Out of gamut colors keep their lightness and hue.
+	var (
+		vivid  = LCH(0.9, 0.4, 250)
+		mapped = vivid.RGB().OKLab()
+	)
+	require.InDelta(t, 0.9, mapped.L, 0.01)
+	require.InDelta(t, 250, mapped.Hue(), 2)
+	require.Less(t, mapped.Chroma(), 0.4)
+
+	require.Equal(t, RGB{R: 255, G: 255, B: 255}, LCH(2, 0, 0).RGB())
+	require.Equal(t, RGB{}, LCH(-1, 0, 0).RGB())
+}
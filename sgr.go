@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var _ Style = SGR("")
+
+// An SGR is a [Style] whose value is its complete, precomputed escape
+// sequence, e.g. "\x1b[1;31m" for bold red text. Unlike other styles, SGRs can
+// be declared as constants, such as those generated by cmd/colorgen. An empty
+// SGR is unstyled.
+type SGR string
+
+// Code returns the SGR parameters of s, e.g. "1;31", or an empty string if s
+// is not an SGR sequence.
+func (s SGR) Code() string {
+	if !IsSGR(string(s)) {
+		return ""
+	}
+	return string(s[2 : len(s)-1])
+}
+
+// String returns s, regardless of whether color is enabled.
+func (s SGR) String() string {
+	return string(s)
+}
+
+// Escape returns s if color is enabled, or an empty string otherwise.
+func (s SGR) Escape() string {
+	if !Enabled() {
+		return ""
+	}
+	return string(s)
+}
+
+// Reset returns the reset escape sequence if s is not empty and color is
+// enabled, or an empty string otherwise.
+func (s SGR) Reset() string {
+	if len(s) == 0 || !Enabled() {
+		return ""
+	}
+	return Reset.String()
+}
+
+// With returns a new [Style] that combines s with styles.
+func (s SGR) With(styles ...Style) Style {
+	if len(styles) == 0 {
+		return s
+	}
+	return newMultiStyle(append([]Style{s}, styles...)...)
+}
+
+// Wrap returns str wrapped in s.
+func (s SGR) Wrap(str string) string {
+	return s.Escape() + str + s.Reset()
+}
+
+// Join wraps each of elems in s and joins them with sep.
+func (s SGR) Join(elems []string, sep string) string {
+	wrapped := make([]string, len(elems))
+	for i, str := range elems {
+		wrapped[i] = s.Wrap(str)
+	}
+	return strings.Join(wrapped, sep)
+}
+
+// Copy copies src to dst as in [io.Copy], but wrapped in s.
+func (s SGR) Copy(dst io.Writer, src io.Reader) (int64, error) {
+	n, err := io.WriteString(dst, s.Escape())
+	if err != nil {
+		return int64(n), err
+	}
+
+	n64, err := io.Copy(dst, src)
+	if err != nil {
+		return int64(n) + n64, err
+	}
+
+	m, err := io.WriteString(dst, s.Reset())
+	return int64(n+m) + n64, err
+}
+
+// Print prints args to stdout as in [fmt.Print], wrapped in s.
+func (s SGR) Print(args ...any) {
+	defer _stdout.Flush()      //nolint:errcheck
+	s.Fprint(_stdout, args...) //nolint:errcheck
+}
+
+// Printf prints a formatted message to stdout as in [fmt.Printf], wrapped in
+// s.
+func (s SGR) Printf(msg string, args ...any) {
+	defer _stdout.Flush()            //nolint:errcheck
+	s.Fprintf(_stdout, msg, args...) //nolint:errcheck
+}
+
+// Println prints args to stdout as in [fmt.Println], wrapped in s.
+func (s SGR) Println(args ...any) {
+	defer _stdout.Flush()        //nolint:errcheck
+	s.Fprintln(_stdout, args...) //nolint:errcheck
+}
+
+// Sprint formats args as in [fmt.Sprint], wrapped in s.
+func (s SGR) Sprint(args ...any) string {
+	return s.Wrap(fmt.Sprint(args...))
+}
+
+// Sprintf formats a message as in [fmt.Sprintf], wrapped in s.
+func (s SGR) Sprintf(msg string, args ...any) string {
+	return s.Wrap(fmt.Sprintf(msg, args...))
+}
+
+// Sprintln formats args as in [fmt.Sprintln], wrapped in s before the
+// trailing newline.
+func (s SGR) Sprintln(args ...any) string {
+	return s.Wrap(strings.TrimSuffix(fmt.Sprintln(args...), "\n")) + "\n"
+}
+
+// Fprint writes args to w as in [fmt.Fprint], wrapped in s.
+func (s SGR) Fprint(w io.Writer, args ...any) (int, error) {
+	return io.WriteString(w, s.Sprint(args...))
+}
+
+// Fprintf writes a formatted message to w as in [fmt.Fprintf], wrapped in s.
+func (s SGR) Fprintf(w io.Writer, msg string, args ...any) (int, error) {
+	return io.WriteString(w, s.Sprintf(msg, args...))
+}
+
+// Fprintln writes args to w as in [fmt.Fprintln], wrapped in s before the
+// trailing newline.
+func (s SGR) Fprintln(w io.Writer, args ...any) (int, error) {
+	return io.WriteString(w, s.Sprintln(args...))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSGR(t *testing.T) {
+	const style SGR = "\x1b[1;38;5;208m"
+
+	wrap := func(str string) string {
+		return string(style) + str + Reset.String()
+	}
+
+	require.Equal(t, "1;38;5;208", style.Code())
+	require.Equal(t, Bold.With(Fg256(208)).String(), style.String())
+	require.Equal(t, string(style), style.Escape())
+	require.Equal(t, Reset.String(), style.Reset())
+	require.Equal(t, wrap("x"), style.Wrap("x"))
+	require.Equal(t, wrap("a")+","+wrap("b"), style.Join([]string{"a", "b"}, ","))
+	require.Equal(t, wrap("a1"), style.Sprint("a", 1))
+	require.Equal(t, wrap("a=1"), style.Sprintf("a=%d", 1))
+	require.Equal(t, wrap("a 1")+"\n", style.Sprintln("a", 1))
+	require.Equal(t, Style(style), style.With())
+	require.Equal(t, "\x1b[1;38;5;208;4m", style.With(Underline).String())
+
+	var buf bytes.Buffer
+	n, err := style.Fprint(&buf, "x")
+	require.NoError(t, err)
+	require.Equal(t, buf.Len(), n)
+	require.Equal(t, wrap("x"), buf.String())
+
+	buf.Reset()
+	_, err = style.Fprintf(&buf, "%d", 2)
+	require.NoError(t, err)
+	require.Equal(t, wrap("2"), buf.String())
+
+	buf.Reset()
+	_, err = style.Fprintln(&buf, "y")
+	require.NoError(t, err)
+	require.Equal(t, wrap("y")+"\n", buf.String())
+
+	buf.Reset()
+	n64, err := style.Copy(&buf, strings.NewReader("z"))
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n64)
+	require.Equal(t, wrap("z"), buf.String())
+}
+
+func TestSGR_Empty(t *testing.T) {
+	var style SGR
+
+	require.Equal(t, "", style.Code())
+	require.Equal(t, "", style.Reset())
+	require.Equal(t, "x", style.Wrap("x"))
+	require.Equal(t, "\x1b[1m", style.With(Bold).String())
+
+	require.Equal(t, "", SGR("abcd").Code())
+	require.Equal(t, "", SGR("\x1b[2K").Code())
+}
+
+func TestSGR_Disabled(t *testing.T) {
+	t.Cleanup(OverrideEnabled(false))
+
+	const style SGR = "\x1b[31m"
+	require.Equal(t, "\x1b[31m", style.String())
+	require.Equal(t, "", style.Escape())
+	require.Equal(t, "", style.Reset())
+	require.Equal(t, "x", style.Wrap("x"))
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package status
+
+import (
+	"time"
+
+	"go.mway.dev/color"
+)
+
+// Mode controls how a [Region] is displayed.
+type Mode int
+
+// Modes.
+const (
+	// ModeAuto redraws in place if the destination writer is a terminal (see
+	// [color.IsTerminal]), and otherwise behaves as ModePlain. Output is
+	// styled only if color is enabled for the writer (see [color.EnabledFor]).
+	ModeAuto Mode = iota
+	// ModeInteractive redraws the region in place using cursor movement.
+	ModeInteractive
+	// ModePlain writes an unstyled line whenever a task's state changes.
+	ModePlain
+)
+
+// Styles are the styles applied to a task's line, according to its state.
+type Styles struct {
+	// Pending is applied to the symbol of pending tasks.
+	Pending color.Style
+	// Running is applied to the symbol of running tasks.
+	Running color.Style
+	// Succeeded is applied to the symbol of succeeded tasks.
+	Succeeded color.Style
+	// Failed is applied to the symbol of failed tasks.
+	Failed color.Style
+	// Skipped is applied to the symbol of skipped tasks.
+	Skipped color.Style
+	// Label is applied to each task's label.
+	Label color.Style
+	// Message is applied to each task's message.
+	Message color.Style
+}
+
+// DefaultStyles returns the default [Styles], based on [color.DefaultTheme].
+func DefaultStyles() Styles {
+	return ThemeStyles(color.DefaultTheme())
+}
+
+// ThemeStyles returns [Styles] that use the roles of the given theme, e.g.
+// [color.ThemeFromEnv].
+func ThemeStyles(theme color.Theme) Styles {
+	return Styles{
+		Pending:   theme.Muted,
+		Running:   theme.Info,
+		Succeeded: theme.Success,
+		Failed:    theme.Error,
+		Skipped:   theme.Warning,
+		Label:     color.Nop,
+		Message:   theme.Muted,
+	}
+}
+
+func (s Styles) state(state State) color.Style {
+	switch state {
+	case StateRunning:
+		return s.Running
+	case StateSucceeded:
+		return s.Succeeded
+	case StateFailed:
+		return s.Failed
+	case StateSkipped:
+		return s.Skipped
+	default:
+		return s.Pending
+	}
+}
+
+func (s Styles) merge(dst *Styles) {
+	for _, x := range []struct {
+		src color.Style
+		dst *color.Style
+	}{
+		{src: s.Pending, dst: &dst.Pending},
+		{src: s.Running, dst: &dst.Running},
+		{src: s.Succeeded, dst: &dst.Succeeded},
+		{src: s.Failed, dst: &dst.Failed},
+		{src: s.Skipped, dst: &dst.Skipped},
+		{src: s.Label, dst: &dst.Label},
+		{src: s.Message, dst: &dst.Message},
+	} {
+		if x.src != nil {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Symbols are the symbols displayed before a task's label, according to its
+// state.
+type Symbols struct {
+	// Pending is displayed for pending tasks.
+	Pending string
+	// Running is displayed for running tasks.
+	Running string
+	// Succeeded is displayed for succeeded tasks.
+	Succeeded string
+	// Failed is displayed for failed tasks.
+	Failed string
+	// Skipped is displayed for skipped tasks.
+	Skipped string
+}
+
+// DefaultSymbols returns the default [Symbols].
+func DefaultSymbols() Symbols {
+	return Symbols{
+		Pending:   "○",
+		Running:   "●",
+		Succeeded: "✓",
+		Failed:    "✗",
+		Skipped:   "-",
+	}
+}
+
+func (s Symbols) state(state State) string {
+	switch state {
+	case StateRunning:
+		return s.Running
+	case StateSucceeded:
+		return s.Succeeded
+	case StateFailed:
+		return s.Failed
+	case StateSkipped:
+		return s.Skipped
+	default:
+		return s.Pending
+	}
+}
+
+func (s Symbols) merge(dst *Symbols) {
+	for _, x := range []struct {
+		src string
+		dst *string
+	}{
+		{src: s.Pending, dst: &dst.Pending},
+		{src: s.Running, dst: &dst.Running},
+		{src: s.Succeeded, dst: &dst.Succeeded},
+		{src: s.Failed, dst: &dst.Failed},
+		{src: s.Skipped, dst: &dst.Skipped},
+	} {
+		if len(x.src) > 0 {
+			*x.dst = x.src
+		}
+	}
+}
+
+// Options configure a [Region].
+type Options struct {
+	// Styles are the styles applied to each task's line. Nil styles are left
+	// unchanged when options are merged.
+	Styles Styles
+	// Symbols are the symbols displayed before each task's label. Empty
+	// symbols are left unchanged when options are merged.
+	Symbols Symbols
+	// Mode controls how the region is displayed.
+	Mode Mode
+	// Width is the maximum visible width of each line, beyond which lines
+	// are truncated so that they do not wrap. Zero means the width of the
+	// terminal, if any (see [color.TerminalSize]).
+	Width int
+	// Height is the height of the terminal in lines. A region never draws
+	// more task lines than fit below the top of the terminal; tasks that do
+	// not fit are collapsed into a single "+N more" line. Zero means the
+	// height of the terminal, if any (see [color.TerminalSize]).
+	Height int
+	// RefreshInterval is the minimum time between in-place redraws.
+	RefreshInterval time.Duration
+	// DisableSync disables synchronized output (DEC mode 2026).
+	DisableSync bool
+	// NowFunc returns the current time.
+	NowFunc func() time.Time
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Styles:          DefaultStyles(),
+		Symbols:         DefaultSymbols(),
+		RefreshInterval: 100 * time.Millisecond,
+		NowFunc:         time.Now,
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	o.Styles.merge(&dst.Styles)
+	o.Symbols.merge(&dst.Symbols)
+	if o.Mode != ModeAuto {
+		dst.Mode = o.Mode
+	}
+	if o.Width > 0 {
+		dst.Width = o.Width
+	}
+	if o.Height > 0 {
+		dst.Height = o.Height
+	}
+	if o.RefreshInterval > 0 {
+		dst.RefreshInterval = o.RefreshInterval
+	}
+	if o.DisableSync {
+		dst.DisableSync = o.DisableSync
+	}
+	if o.NowFunc != nil {
+		dst.NowFunc = o.NowFunc
+	}
+}
+
+// An Option configures a [Region].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithStyles returns an [Option] that sets the styles applied to each task's
+// line. Nil styles in styles are ignored.
+func WithStyles(styles Styles) Option {
+	return optionFunc(func(o *Options) {
+		styles.merge(&o.Styles)
+	})
+}
+
+// WithSymbols returns an [Option] that sets the symbols displayed before each
+// task's label. Empty symbols in symbols are ignored.
+func WithSymbols(symbols Symbols) Option {
+	return optionFunc(func(o *Options) {
+		symbols.merge(&o.Symbols)
+	})
+}
+
+// WithMode returns an [Option] that controls how the region is displayed.
+func WithMode(mode Mode) Option {
+	return optionFunc(func(o *Options) {
+		o.Mode = mode
+	})
+}
+
+// WithWidth returns an [Option] that sets the maximum visible width of each
+// line.
+func WithWidth(width int) Option {
+	return optionFunc(func(o *Options) {
+		o.Width = width
+	})
+}
+
+// WithHeight returns an [Option] that sets the height of the terminal in
+// lines.
+func WithHeight(height int) Option {
+	return optionFunc(func(o *Options) {
+		o.Height = height
+	})
+}
+
+// WithRefreshInterval returns an [Option] that sets the minimum time between
+// in-place redraws.
+func WithRefreshInterval(interval time.Duration) Option {
+	return optionFunc(func(o *Options) {
+		o.RefreshInterval = interval
+	})
+}
+
+// WithSync returns an [Option] that controls whether redraws use
+// synchronized output (DEC mode 2026).
+func WithSync(enabled bool) Option {
+	return optionFunc(func(o *Options) {
+		o.DisableSync = !enabled
+	})
+}
+
+// WithNowFunc returns an [Option] that sets the function used to get the
+// current time.
+func WithNowFunc(fn func() time.Time) Option {
+	return optionFunc(func(o *Options) {
+		o.NowFunc = fn
+	})
+}
@@ -0,0 +1,389 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package status provides a live region of task status lines that is
+// redrawn in place, with log lines interleaved above it.
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mway.dev/color"
+	"go.mway.dev/color/cursor"
+)
+
+var _lineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// A State is the state of a [Task].
+type State int
+
+// Task states.
+const (
+	// StatePending is a task that has not started.
+	StatePending State = iota
+	// StateRunning is a task that is running.
+	StateRunning
+	// StateSucceeded is a task that finished successfully.
+	StateSucceeded
+	// StateFailed is a task that failed.
+	StateFailed
+	// StateSkipped is a task that was skipped.
+	StateSkipped
+)
+
+// String returns a human-readable name for s.
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateRunning:
+		return "running"
+	case StateSucceeded:
+		return "succeeded"
+	case StateFailed:
+		return "failed"
+	case StateSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// A Region is a live area of task status lines at the bottom of a terminal.
+// When interactive, the region is redrawn in place as tasks change, at most
+// once per refresh interval, and lines logged with [Region.Println] or
+// [Region.Write] are printed above it. Otherwise, a line is written whenever
+// a task's state changes. A Region is safe for concurrent use.
+type Region struct {
+	mu          sync.Mutex
+	w           io.Writer
+	opts        Options
+	interactive bool
+	styled      bool
+	tasks       []*Task
+	drawn       int
+	lastDraw    time.Time
+	timer       *time.Timer
+	partial     []byte
+	stopped     bool
+}
+
+var _ io.Writer = (*Region)(nil)
+
+// New creates a new [Region] that writes to w. Nothing is drawn until a task
+// is added.
+func New(w io.Writer, opts ...Option) *Region {
+	var (
+		options     = DefaultOptions().With(opts...)
+		interactive = options.Mode == ModeInteractive ||
+			(options.Mode == ModeAuto && color.IsTerminal(w))
+	)
+	return &Region{
+		w:           w,
+		opts:        options,
+		interactive: interactive,
+		styled:      interactive && color.EnabledFor(w),
+	}
+}
+
+// Add adds a pending task with the given label to the bottom of r.
+func (r *Region) Add(label string) *Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := &Task{
+		region: r,
+		label:  oneLine(label),
+	}
+	r.tasks = append(r.tasks, t)
+	r.update()
+	return t
+}
+
+// Println formats its arguments as [fmt.Sprintln] does and prints them above
+// r, followed by a redraw of r.
+func (r *Region) Println(args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.log(fmt.Sprintln(args...))
+}
+
+// Write prints the complete lines in p above r, followed by a redraw of r.
+// Partial lines are buffered until they are completed by a subsequent write
+// or [Region.Stop], which allows r to be used as the output of e.g. a
+// [log.Logger]. It never returns an error.
+func (r *Region) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.partial = append(r.partial, p...)
+	if i := bytes.LastIndexByte(r.partial, '\n'); i >= 0 {
+		r.log(string(r.partial[:i+1]))
+		r.partial = r.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Refresh redraws r immediately, regardless of the refresh interval.
+func (r *Region) Refresh() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.interactive && !r.stopped {
+		r.draw("")
+	}
+}
+
+// Stop draws r's final state and flushes any buffered partial line. The
+// region is left on screen, and subsequent log lines are printed below it.
+// Subsequent calls have no effect.
+func (r *Region) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return
+	}
+
+	var logs string
+	if len(r.partial) > 0 {
+		logs = string(r.partial) + "\n"
+		r.partial = nil
+	}
+
+	if r.interactive {
+		r.draw(logs)
+	} else {
+		io.WriteString(r.w, logs) //nolint:errcheck
+	}
+	r.stopped = true
+}
+
+// log prints lines, which must end with a newline, above r.
+func (r *Region) log(lines string) {
+	if !r.interactive || r.stopped {
+		io.WriteString(r.w, lines) //nolint:errcheck
+		return
+	}
+	r.draw(lines)
+}
+
+// update redraws r if enough time has passed since the previous draw, and
+// otherwise schedules a redraw for when it has.
+func (r *Region) update() {
+	if !r.interactive || r.stopped {
+		return
+	}
+
+	wait := r.opts.RefreshInterval - r.opts.NowFunc().Sub(r.lastDraw)
+	if wait <= 0 {
+		r.draw("")
+		return
+	}
+
+	if r.timer == nil {
+		r.timer = time.AfterFunc(wait, r.flush)
+	}
+}
+
+func (r *Region) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timer = nil
+	if !r.stopped {
+		r.draw("")
+	}
+}
+
+// draw moves the cursor to the top of r, prints logs, and redraws r's tasks
+// below them.
+func (r *Region) draw(logs string) {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.lastDraw = r.opts.NowFunc()
+
+	var buf strings.Builder
+	if !r.opts.DisableSync {
+		buf.WriteString(cursor.BeginSync.String())
+	}
+	buf.WriteString(cursor.PrevLine(r.drawn).String())
+
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		if len(line) > 0 {
+			buf.WriteString(strings.TrimSuffix(line, "\n"))
+			buf.WriteString(cursor.EraseLineRight.String() + "\n")
+		}
+	}
+
+	lines := r.lines()
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString(cursor.EraseLineRight.String() + "\n")
+	}
+	buf.WriteString(cursor.EraseDown.String())
+
+	if !r.opts.DisableSync {
+		buf.WriteString(cursor.EndSync.String())
+	}
+
+	r.drawn = len(lines)
+	io.WriteString(r.w, buf.String()) //nolint:errcheck
+}
+
+// lines returns the lines of r's tasks. If there are more tasks than fit on
+// the terminal, with room for the cursor below them, the last lines are
+// collapsed into a "+N more" line, so that the cursor can always move back
+// to the top of r.
+func (r *Region) lines() []string {
+	var (
+		width, height = r.size()
+		tasks         = r.tasks
+		more          int
+	)
+
+	if limit := max(height-1, 1); height > 0 && len(tasks) > limit {
+		more = len(tasks) - (limit - 1)
+		tasks = tasks[:limit-1]
+	}
+
+	lines := make([]string, 0, len(tasks)+1)
+	for _, t := range tasks {
+		lines = append(lines, truncate(r.render(t), width))
+	}
+	if more > 0 {
+		line := r.style(r.opts.Styles.Message).Wrap(fmt.Sprintf("+%d more", more))
+		lines = append(lines, truncate(line, width))
+	}
+	return lines
+}
+
+// render returns t's line.
+func (r *Region) render(t *Task) string {
+	var (
+		styles = r.opts.Styles
+		line   = r.style(styles.state(t.state)).Wrap(r.opts.Symbols.state(t.state))
+	)
+
+	if len(t.label) > 0 {
+		line += " " + r.style(styles.Label).Wrap(t.label)
+	}
+	if len(t.message) > 0 {
+		line += " " + r.style(styles.Message).Wrap(t.message)
+	}
+
+	return line
+}
+
+// size returns the maximum visible width of r's lines and the height of the
+// terminal: the configured values if any, or otherwise the current size of
+// the terminal r writes to. Lines must not wrap or scroll off the top of the
+// terminal when redrawing in place, or the cursor would not return to the
+// top of r.
+func (r *Region) size() (width int, height int) {
+	width, height = r.opts.Width, r.opts.Height
+	if width <= 0 || height <= 0 {
+		termWidth, termHeight := color.TerminalSize(r.w)
+		if width <= 0 {
+			width = termWidth
+		}
+		if height <= 0 {
+			height = termHeight
+		}
+	}
+	return width, height
+}
+
+// truncate truncates line to width, if width is positive.
+func truncate(line string, width int) string {
+	if width > 0 {
+		return color.Truncate(line, width, "…")
+	}
+	return line
+}
+
+// style returns s if r redraws in place and color is enabled for its writer,
+// or [color.Nop] otherwise.
+func (r *Region) style(s color.Style) color.Style {
+	if !r.styled || s == nil {
+		return color.Nop
+	}
+	return s
+}
+
+// A Task is a line in a [Region]. It is safe for concurrent use.
+type Task struct {
+	region  *Region
+	label   string
+	message string
+	state   State
+}
+
+// Set sets t's state and message, redrawing its region if necessary.
+func (t *Task) Set(state State, message string) {
+	t.region.mu.Lock()
+	defer t.region.mu.Unlock()
+
+	t.set(state, message)
+}
+
+// SetMessage sets t's message without changing its state.
+func (t *Task) SetMessage(message string) {
+	t.region.mu.Lock()
+	defer t.region.mu.Unlock()
+
+	t.set(t.state, message)
+}
+
+// State returns t's state.
+func (t *Task) State() State {
+	t.region.mu.Lock()
+	defer t.region.mu.Unlock()
+
+	return t.state
+}
+
+func (t *Task) set(state State, message string) {
+	r := t.region
+	changed := state != t.state
+	t.state = state
+	t.message = oneLine(message)
+
+	if !r.interactive && changed && !r.stopped {
+		width, _ := r.size()
+		io.WriteString(r.w, truncate(r.render(t), width)+"\n") //nolint:errcheck
+		return
+	}
+	r.update()
+}
+
+// oneLine replaces the line breaks in str with spaces, so that it does not
+// break the region's layout.
+func oneLine(str string) string {
+	return _lineBreaks.Replace(str)
+}
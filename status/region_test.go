@@ -0,0 +1,315 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package status_test
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/cursor"
+	"go.mway.dev/color/status"
+	"go.mway.dev/color/vt"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// frame returns the expected output of a redraw that moves up over drawn
+// lines and draws lines.
+func frame(drawn int, lines ...string) string {
+	out := cursor.BeginSync.String() + cursor.PrevLine(drawn).String()
+	for _, line := range lines {
+		out += line + cursor.EraseLineRight.String() + "\n"
+	}
+	return out + cursor.EraseDown.String() + cursor.EndSync.String()
+}
+
+func TestState_String(t *testing.T) {
+	require.Equal(t, "pending", status.StatePending.String())
+	require.Equal(t, "running", status.StateRunning.String())
+	require.Equal(t, "succeeded", status.StateSucceeded.String())
+	require.Equal(t, "failed", status.StateFailed.String())
+	require.Equal(t, "skipped", status.StateSkipped.String())
+	require.Equal(t, "unknown", status.State(-1).String())
+}
+
+func TestRegion_Interactive(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		clock  = &fakeClock{now: time.Unix(0, 0)}
+		buf    bytes.Buffer
+		styles = status.DefaultStyles()
+		region = status.New(
+			&buf,
+			status.WithMode(status.ModeInteractive),
+			status.WithNowFunc(clock.Now),
+			status.WithRefreshInterval(time.Hour),
+		)
+	)
+
+	clock.Add(2 * time.Hour)
+	build := region.Add("build")
+	require.Equal(t, frame(0, styles.Pending.Wrap("○")+" build"), buf.String())
+
+	// Updates within the refresh interval are deferred.
+	buf.Reset()
+	test := region.Add("test")
+	build.Set(status.StateRunning, "compiling\nmain.go")
+	require.Empty(t, buf.String())
+
+	region.Refresh()
+	require.Equal(
+		t,
+		frame(
+			1,
+			styles.Running.Wrap("●")+" build "+styles.Message.Wrap("compiling main.go"),
+			styles.Pending.Wrap("○")+" test",
+		),
+		buf.String(),
+	)
+
+	// Log lines are printed above the region, which is redrawn below them.
+	buf.Reset()
+	region.Println("hello", "world")
+	require.Equal(
+		t,
+		frame(
+			2,
+			"hello world",
+			styles.Running.Wrap("●")+" build "+styles.Message.Wrap("compiling main.go"),
+			styles.Pending.Wrap("○")+" test",
+		),
+		buf.String(),
+	)
+
+	buf.Reset()
+	build.Set(status.StateSucceeded, "")
+	test.Set(status.StateFailed, "exit 1")
+	require.Equal(t, status.StateFailed, test.State())
+	region.Stop()
+	region.Stop()
+	require.Equal(
+		t,
+		frame(
+			2,
+			styles.Succeeded.Wrap("✓")+" build",
+			styles.Failed.Wrap("✗")+" test "+styles.Message.Wrap("exit 1"),
+		),
+		buf.String(),
+	)
+
+	// Once stopped, the region is left as is.
+	buf.Reset()
+	region.Println("done")
+	test.SetMessage("ignored")
+	region.Refresh()
+	require.Equal(t, "done\n", buf.String())
+}
+
+func TestRegion_Throttle(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		buf    syncBuffer
+		region = status.New(
+			&buf,
+			status.WithMode(status.ModeInteractive),
+			status.WithRefreshInterval(10*time.Millisecond),
+			status.WithSync(false),
+		)
+	)
+
+	task := region.Add("a")
+	task.SetMessage("1")
+	task.SetMessage("2")
+
+	require.Eventually(t, func() bool {
+		return buf.String() == "○ a"+cursor.EraseLineRight.String()+"\n"+
+			cursor.EraseDown.String()+
+			cursor.PrevLine(1).String()+
+			"○ a 2"+cursor.EraseLineRight.String()+"\n"+
+			cursor.EraseDown.String()
+	}, time.Second, time.Millisecond)
+
+	region.Stop()
+}
+
+func TestRegion_Write(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		buf    bytes.Buffer
+		region = status.New(
+			&buf,
+			status.WithMode(status.ModeInteractive),
+			status.WithSymbols(status.Symbols{Pending: "-"}),
+			status.WithWidth(5),
+		)
+		logger = log.New(region, "", 0)
+	)
+
+	region.Add("a long label")
+	buf.Reset()
+
+	n, err := region.Write([]byte("partial"))
+	require.NoError(t, err)
+	require.Equal(t, 7, n)
+	require.Empty(t, buf.String())
+
+	logger.Print("one\ntwo")
+	require.Equal(t, frame(1, "partialone", "two", "- a …"), buf.String())
+
+	buf.Reset()
+	region.Write([]byte("tail")) //nolint:errcheck
+	region.Stop()
+	require.Equal(t, frame(1, "tail", "- a …"), buf.String())
+}
+
+func TestRegion_Plain(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		buf    bytes.Buffer
+		region = status.New(&buf, status.WithMode(status.ModePlain))
+	)
+
+	task := region.Add("deploy")
+	task.SetMessage("waiting")
+	region.Println("log")
+	task.Set(status.StateRunning, "step 1")
+	task.SetMessage("step 2")
+	task.Set(status.StateSkipped, "")
+	region.Write([]byte("tail")) //nolint:errcheck
+	region.Stop()
+
+	require.Equal(t, "log\n● deploy step 1\n- deploy\ntail\n", buf.String())
+}
+
+func TestRegion_Auto(t *testing.T) {
+	// Color being enabled does not make a non-terminal writer interactive.
+	colortest.ForceColor(t, true)
+
+	var buf bytes.Buffer
+	region := status.New(&buf)
+	region.Add("x").Set(status.StateSucceeded, "ok")
+	region.Stop()
+
+	require.Equal(t, "✓ x ok\n", buf.String())
+}
+
+func TestThemeStyles(t *testing.T) {
+	theme := color.CVDTheme(color.Deuteranopia)
+	styles := status.ThemeStyles(theme)
+	require.Equal(t, theme.Error, styles.Failed)
+	require.Equal(t, theme.Success, styles.Succeeded)
+
+	colortest.ForceColor(t, true)
+
+	var (
+		buf    bytes.Buffer
+		region = status.New(
+			&buf,
+			status.WithMode(status.ModeInteractive),
+			status.WithStyles(status.Styles{Failed: color.FgMagenta}),
+			status.WithSync(false),
+		)
+	)
+	region.Add("x").Set(status.StateFailed, "")
+	region.Refresh()
+	require.Contains(t, buf.String(), color.FgMagenta.Wrap("✗")+" x")
+}
+
+func TestRegion_Unstyled(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		buf    bytes.Buffer
+		region = status.New(&buf, status.WithMode(status.ModeInteractive), status.WithSync(false))
+	)
+	region.Add("x").Set(status.StateFailed, "")
+	region.Refresh()
+
+	require.Contains(t, buf.String(), "✗ x")
+	require.NotContains(t, buf.String(), status.DefaultStyles().Failed.String())
+}
+
+func TestRegion_Height(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	var (
+		screen = vt.New(20, 4)
+		region = status.New(
+			screen,
+			status.WithMode(status.ModeInteractive),
+			status.WithRefreshInterval(time.Nanosecond),
+			status.WithHeight(4),
+		)
+		tasks []*status.Task
+	)
+
+	for _, label := range []string{"a", "b", "c", "d", "e"} {
+		tasks = append(tasks, region.Add(label))
+	}
+	for i, task := range tasks {
+		task.Set(status.StateRunning, "")
+		region.Println("log", i)
+	}
+	tasks[0].Set(status.StateSucceeded, "")
+	region.Refresh()
+
+	// The region and the cursor below it fit on the screen, so redraws never
+	// leave copies of task lines in the scrollback.
+	require.Equal(t, []string{"✓ a", "● b", "+3 more", ""}, screen.Lines())
+	require.Equal(t, []string{"log 0", "log 1", "log 2", "log 3", "log 4"}, screen.Scrollback())
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package table
+
+import (
+	"go.mway.dev/color"
+	"go.mway.dev/color/border"
+)
+
+// Options configure a [Table].
+type Options struct {
+	// Border is the set of characters used to draw the table's borders.
+	Border border.Set
+	// BorderStyle is the style used to draw the table's borders.
+	BorderStyle color.Style
+	// HeaderStyle is the style used to draw header cells.
+	HeaderStyle color.Style
+	// StripeStyles, if not empty, are applied to body rows in rotation, e.g.
+	// to alternate backgrounds.
+	StripeStyles []color.Style
+	// Padding is the number of spaces on either side of each cell.
+	Padding int
+	// MaxWidth, if positive, is the maximum visible width of the table.
+	// Columns are narrowed (widest first) and their cells truncated to fit.
+	MaxWidth int
+	// Ellipsis replaces text removed from truncated cells.
+	Ellipsis string
+	// RowSeparators controls whether a horizontal rule is drawn between body
+	// rows.
+	RowSeparators bool
+}
+
+// DefaultOptions returns a new [Options] with default values.
+func DefaultOptions() Options {
+	return Options{
+		Border:      border.Single,
+		BorderStyle: color.Nop,
+		HeaderStyle: color.Bold,
+		Padding:     1,
+		Ellipsis:    "…",
+	}
+}
+
+// With returns a new [Options] based on o with the given options applied.
+func (o Options) With(opts ...Option) Options {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o Options) apply(dst *Options) {
+	if !o.Border.IsZero() {
+		dst.Border = o.Border
+	}
+	if o.BorderStyle != nil {
+		dst.BorderStyle = o.BorderStyle
+	}
+	if o.HeaderStyle != nil {
+		dst.HeaderStyle = o.HeaderStyle
+	}
+	if len(o.StripeStyles) > 0 {
+		dst.StripeStyles = o.StripeStyles
+	}
+	if o.Padding > 0 {
+		dst.Padding = o.Padding
+	}
+	if o.MaxWidth > 0 {
+		dst.MaxWidth = o.MaxWidth
+	}
+	if len(o.Ellipsis) > 0 {
+		dst.Ellipsis = o.Ellipsis
+	}
+	if o.RowSeparators {
+		dst.RowSeparators = o.RowSeparators
+	}
+}
+
+// An Option configures a [Table].
+type Option interface {
+	apply(*Options)
+}
+
+type optionFunc func(*Options)
+
+func (f optionFunc) apply(o *Options) {
+	f(o)
+}
+
+// WithBorder returns an [Option] that sets the table's border characters.
+func WithBorder(set border.Set) Option {
+	return optionFunc(func(o *Options) {
+		o.Border = set
+	})
+}
+
+// WithBorderStyle returns an [Option] that sets the table's border style. A
+// nil style is treated as [color.Nop].
+func WithBorderStyle(style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.BorderStyle = orNop(style)
+	})
+}
+
+// WithHeaderStyle returns an [Option] that sets the style of header cells. A
+// nil style is treated as [color.Nop].
+func WithHeaderStyle(style color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.HeaderStyle = orNop(style)
+	})
+}
+
+// WithStripes returns an [Option] that applies styles to body rows in
+// rotation (zebra striping).
+func WithStripes(styles ...color.Style) Option {
+	return optionFunc(func(o *Options) {
+		o.StripeStyles = styles
+	})
+}
+
+// WithPadding returns an [Option] that sets the number of spaces on either
+// side of each cell.
+func WithPadding(padding int) Option {
+	return optionFunc(func(o *Options) {
+		o.Padding = padding
+	})
+}
+
+// WithMaxWidth returns an [Option] that limits the table's visible width.
+func WithMaxWidth(width int) Option {
+	return optionFunc(func(o *Options) {
+		o.MaxWidth = width
+	})
+}
+
+// WithEllipsis returns an [Option] that sets the text that replaces text
+// removed from truncated cells.
+func WithEllipsis(ellipsis string) Option {
+	return optionFunc(func(o *Options) {
+		o.Ellipsis = ellipsis
+	})
+}
+
+// WithRowSeparators returns an [Option] that controls whether horizontal
+// rules are drawn between body rows.
+func WithRowSeparators(enabled bool) Option {
+	return optionFunc(func(o *Options) {
+		o.RowSeparators = enabled
+	})
+}
+
+// orNop returns style, or [color.Nop] if style is nil.
+func orNop(style color.Style) color.Style {
+	if style == nil {
+		return color.Nop
+	}
+	return style
+}
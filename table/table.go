@@ -0,0 +1,357 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package table renders tables whose cells may contain styled text.
+package table
+
+import (
+	"io"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+// Align is the horizontal alignment of text within a column.
+type Align int
+
+// Alignments.
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// A Column configures a single column of a [Table].
+type Column struct {
+	// Align is the alignment of the column's cells.
+	Align Align
+	// Style is applied to each of the column's body cells.
+	Style color.Style
+	// MaxWidth, if positive, is the maximum visible width of the column's
+	// cells, excluding padding.
+	MaxWidth int
+}
+
+type cellKey struct {
+	row int
+	col int
+}
+
+// A Table is a grid of cells with an optional header. Cells may contain
+// styled text (e.g. from [color.Style.Sprint]); widths are measured in
+// visible cells, ignoring escape sequences. Cells may contain newlines.
+type Table struct {
+	opts       Options
+	header     []string
+	rows       [][]string
+	columns    map[int]Column
+	rowStyles  map[int]color.Style
+	cellStyles map[cellKey]color.Style
+}
+
+// New creates a new, empty [Table] with the given options.
+func New(opts ...Option) *Table {
+	return &Table{
+		opts:       DefaultOptions().With(opts...),
+		columns:    make(map[int]Column),
+		rowStyles:  make(map[int]color.Style),
+		cellStyles: make(map[cellKey]color.Style),
+	}
+}
+
+// SetHeader sets the header cells of t.
+func (t *Table) SetHeader(cells ...string) *Table {
+	t.header = append([]string(nil), cells...)
+	return t
+}
+
+// AddRow appends a body row to t.
+func (t *Table) AddRow(cells ...string) *Table {
+	t.rows = append(t.rows, append([]string(nil), cells...))
+	return t
+}
+
+// SetColumn configures the column at index idx.
+func (t *Table) SetColumn(idx int, col Column) *Table {
+	t.columns[idx] = col
+	return t
+}
+
+// SetRowStyle sets the style of the body row at index row. Row styles take
+// precedence over stripe and column styles.
+func (t *Table) SetRowStyle(row int, style color.Style) *Table {
+	t.rowStyles[row] = style
+	return t
+}
+
+// SetCellStyle sets the style of the body cell at the given row and column.
+// Cell styles take precedence over all other styles.
+func (t *Table) SetCellStyle(row int, col int, style color.Style) *Table {
+	t.cellStyles[cellKey{row: row, col: col}] = style
+	return t
+}
+
+// String renders t.
+func (t *Table) String() string {
+	var buf strings.Builder
+	t.WriteTo(&buf) //nolint:errcheck
+	return buf.String()
+}
+
+// WriteTo renders t to w.
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	header, rows := t.split()
+	if len(header) == 0 && len(rows) == 0 {
+		return 0, nil
+	}
+
+	r := renderer{
+		opts:   t.opts,
+		widths: t.widths(header, rows),
+	}
+
+	r.rule(r.opts.Border.TopLeft, r.opts.Border.TopT, r.opts.Border.TopRight)
+	if len(header) > 0 {
+		r.row(header, func(col int) (color.Style, Align) {
+			return t.opts.HeaderStyle, t.columns[col].Align
+		})
+		r.rule(r.opts.Border.LeftT, r.opts.Border.Cross, r.opts.Border.RightT)
+	}
+
+	for i, row := range rows {
+		if i > 0 && t.opts.RowSeparators {
+			r.rule(r.opts.Border.LeftT, r.opts.Border.Cross, r.opts.Border.RightT)
+		}
+		r.row(row, func(col int) (color.Style, Align) {
+			return t.style(i, col), t.columns[col].Align
+		})
+	}
+	r.rule(r.opts.Border.BottomLeft, r.opts.Border.BottomT, r.opts.Border.BottomRight)
+
+	n, err := io.WriteString(w, r.buf.String())
+	return int64(n), err
+}
+
+// split splits each cell into lines, padding rows to the same number of
+// columns.
+func (t *Table) split() ([][]string, [][][]string) {
+	ncols := len(t.header)
+	for _, row := range t.rows {
+		ncols = max(ncols, len(row))
+	}
+
+	splitRow := func(cells []string) [][]string {
+		dst := make([][]string, ncols)
+		for i := range dst {
+			if i < len(cells) {
+				dst[i] = color.SplitLines(cells[i])
+			} else {
+				dst[i] = []string{""}
+			}
+		}
+		return dst
+	}
+
+	var header [][]string
+	if len(t.header) > 0 {
+		header = splitRow(t.header)
+	}
+
+	rows := make([][][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = splitRow(row)
+	}
+
+	return header, rows
+}
+
+// widths returns the content width of each column.
+func (t *Table) widths(header [][]string, rows [][][]string) []int {
+	ncols := len(header)
+	if len(rows) > 0 {
+		ncols = len(rows[0])
+	}
+
+	widths := make([]int, ncols)
+	measure := func(row [][]string) {
+		for i, lines := range row {
+			for _, line := range lines {
+				widths[i] = max(widths[i], color.VisibleWidth(line))
+			}
+		}
+	}
+
+	measure(header)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	for i := range widths {
+		if limit := t.columns[i].MaxWidth; limit > 0 {
+			widths[i] = min(widths[i], limit)
+		}
+	}
+
+	if t.opts.MaxWidth > 0 {
+		shrink(widths, t.opts.MaxWidth-t.overhead(len(widths)))
+	}
+
+	return widths
+}
+
+// overhead returns the number of cells used by borders and padding.
+func (t *Table) overhead(ncols int) int {
+	n := ncols * 2 * t.opts.Padding
+	if !t.opts.Border.IsZero() {
+		n += ncols + 1
+	}
+	return n
+}
+
+// style returns the style of the given body cell.
+func (t *Table) style(row int, col int) color.Style {
+	var stripe color.Style
+	if n := len(t.opts.StripeStyles); n > 0 {
+		stripe = t.opts.StripeStyles[row%n]
+	}
+
+	styles := make([]color.Style, 0, 4)
+	for _, style := range []color.Style{
+		t.columns[col].Style,
+		stripe,
+		t.rowStyles[row],
+		t.cellStyles[cellKey{row: row, col: col}],
+	} {
+		if style != nil {
+			styles = append(styles, style)
+		}
+	}
+
+	switch len(styles) {
+	case 0:
+		return color.Nop
+	case 1:
+		return styles[0]
+	default:
+		return color.Combine(styles...)
+	}
+}
+
+// shrink narrows the widest of widths until their sum is at most total. Each
+// width is kept at 1 or more.
+func shrink(widths []int, total int) {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+
+	for sum > total {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= 1 {
+			return
+		}
+		widths[widest]--
+		sum--
+	}
+}
+
+type renderer struct {
+	opts   Options
+	widths []int
+	buf    strings.Builder
+}
+
+func (r *renderer) rule(left string, junction string, right string) {
+	set := r.opts.Border
+	if set.IsZero() {
+		return
+	}
+
+	var line strings.Builder
+	line.WriteString(left)
+	for i, width := range r.widths {
+		if i > 0 {
+			line.WriteString(junction)
+		}
+		line.WriteString(strings.Repeat(set.Horizontal, width+2*r.opts.Padding))
+	}
+	line.WriteString(right)
+
+	r.buf.WriteString(r.opts.BorderStyle.Wrap(line.String()))
+	r.buf.WriteByte('\n')
+}
+
+func (r *renderer) row(cells [][]string, format func(int) (color.Style, Align)) {
+	height := 0
+	for _, lines := range cells {
+		height = max(height, len(lines))
+	}
+
+	var (
+		pad      = strings.Repeat(" ", r.opts.Padding)
+		vertical string
+	)
+	if len(r.opts.Border.Vertical) > 0 {
+		vertical = r.opts.BorderStyle.Wrap(r.opts.Border.Vertical)
+	}
+
+	for n := 0; n < height; n++ {
+		r.buf.WriteString(vertical)
+		for i, lines := range cells {
+			if i > 0 {
+				r.buf.WriteString(vertical)
+			}
+
+			var line string
+			if n < len(lines) {
+				line = lines[n]
+			}
+
+			style, align := format(i)
+			line = color.Truncate(line, r.widths[i], r.opts.Ellipsis)
+			// Re-apply the cell's style after any reset within the cell, so
+			// that e.g. a row background spans the whole cell.
+			r.buf.WriteString(color.WrapNested(style, pad+justify(line, r.widths[i], align)+pad))
+		}
+		r.buf.WriteString(vertical)
+		r.buf.WriteByte('\n')
+	}
+}
+
+func justify(str string, width int, align Align) string {
+	gap := width - color.VisibleWidth(str)
+	if gap <= 0 {
+		return str
+	}
+
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + str
+	case AlignCenter:
+		return strings.Repeat(" ", gap/2) + str + strings.Repeat(" ", gap-gap/2)
+	default:
+		return str + strings.Repeat(" ", gap)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package table_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/border"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/table"
+)
+
+func lines(strs ...string) string {
+	return strings.Join(strs, "\n") + "\n"
+}
+
+func TestTable(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	tbl := table.New().
+		SetHeader("name", "count").
+		AddRow("foo", "1").
+		AddRow(color.FgRed.Sprint("barbaz"), "22").
+		SetColumn(1, table.Column{Align: table.AlignRight})
+
+	require.Equal(t, lines(
+		"┌────────┬───────┐",
+		"│ name   │ count │",
+		"├────────┼───────┤",
+		"│ foo    │     1 │",
+		"│ barbaz │    22 │",
+		"└────────┴───────┘",
+	), tbl.String())
+
+	var buf bytes.Buffer
+	n, err := tbl.WriteTo(&buf)
+	require.NoError(t, err)
+	require.EqualValues(t, buf.Len(), n)
+	require.Equal(t, tbl.String(), buf.String())
+
+	require.Equal(t, "", table.New().String())
+}
+
+func TestTable_Escapes(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	tbl := table.New(table.WithBorder(border.ASCII)).
+		AddRow(color.FgRed.Sprint("ab"), "c").
+		AddRow("d", color.Bold.Sprint("efg"))
+
+	require.Equal(t, lines(
+		"+----+-----+",
+		"| "+color.FgRed.Wrap("ab")+" | c   |",
+		"| d  | "+color.Bold.Wrap("efg")+" |",
+		"+----+-----+",
+	), tbl.String())
+}
+
+func TestTable_Styles(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		bdr = color.FgBlue
+		hdr = color.Bold
+		odd = color.BgBlack
+	)
+
+	tbl := table.New(
+		table.WithBorder(border.ASCII),
+		table.WithBorderStyle(bdr),
+		table.WithHeaderStyle(hdr),
+		table.WithStripes(color.Nop, odd),
+		table.WithPadding(0),
+	).
+		SetHeader("a", "b").
+		AddRow("1", "2").
+		AddRow("3", "4").
+		AddRow("5", "6").
+		SetColumn(0, table.Column{Style: color.FgGreen}).
+		SetRowStyle(2, color.FgYellow).
+		SetCellStyle(2, 1, color.FgRed)
+
+	var (
+		rule = bdr.Wrap("+-+-+")
+		bar  = bdr.Wrap("|")
+	)
+
+	require.Equal(t, lines(
+		rule,
+		bar+hdr.Wrap("a")+bar+hdr.Wrap("b")+bar,
+		rule,
+		bar+color.Combine(color.FgGreen, color.Nop).Wrap("1")+bar+color.Nop.Wrap("2")+bar,
+		bar+color.Combine(color.FgGreen, odd).Wrap("3")+bar+odd.Wrap("4")+bar,
+		bar+color.Combine(color.FgGreen, color.Nop, color.FgYellow).Wrap("5")+bar+
+			color.Combine(color.Nop, color.FgYellow, color.FgRed).Wrap("6")+bar,
+		rule,
+	), tbl.String())
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	tbl := table.New(
+		table.WithBorder(border.ASCII),
+		table.WithMaxWidth(16),
+		table.WithRowSeparators(true),
+	).
+		AddRow("abcdefghij", "klmnopqrst").
+		AddRow("x", "y").
+		SetColumn(0, table.Column{MaxWidth: 4})
+
+	require.Equal(t, lines(
+		"+------+-------+",
+		"| abc… | klmn… |",
+		"+------+-------+",
+		"| x    | y     |",
+		"+------+-------+",
+	), tbl.String())
+}
+
+func TestTable_Multiline(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	tbl := table.New(table.WithBorder(border.None)).
+		AddRow(color.FgRed.Sprint("a\nbc"), "d")
+
+	require.Equal(t, lines(
+		" "+color.FgRed.Wrap("a")+"  "+" d ",
+		" "+color.FgRed.Wrap("bc")+" "+"   ",
+	), tbl.String())
+}
+
+func TestOptions(t *testing.T) {
+	opts := table.DefaultOptions()
+	require.Equal(t, border.Single, opts.Border)
+	require.Equal(t, 1, opts.Padding)
+
+	opts = table.DefaultOptions().With(table.Options{
+		Border:        border.Double,
+		BorderStyle:   color.FgRed,
+		HeaderStyle:   color.FgBlue,
+		StripeStyles:  []color.Style{color.BgBlack},
+		Padding:       2,
+		MaxWidth:      80,
+		Ellipsis:      "...",
+		RowSeparators: true,
+	}, table.WithEllipsis("~"))
+	require.Equal(t, border.Double, opts.Border)
+	require.Equal(t, color.FgRed, opts.BorderStyle)
+	require.Equal(t, color.FgBlue, opts.HeaderStyle)
+	require.Len(t, opts.StripeStyles, 1)
+	require.Equal(t, 2, opts.Padding)
+	require.Equal(t, 80, opts.MaxWidth)
+	require.Equal(t, "~", opts.Ellipsis)
+	require.True(t, opts.RowSeparators)
+}
+
+func TestTable_NilStyles(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	tbl := table.New(
+		table.WithBorder(border.ASCII),
+		table.WithBorderStyle(nil),
+		table.WithHeaderStyle(nil),
+		table.WithStripes(nil, color.BgBlack),
+		table.WithPadding(0),
+	).
+		SetHeader("a").
+		AddRow("b").
+		SetRowStyle(0, nil).
+		SetCellStyle(0, 0, nil)
+
+	require.Equal(t, lines("+-+", "|a|", "+-+", "|b|", "+-+"), tbl.String())
+}
+
+func TestTable_NestedReset(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		bg  = color.BgBlue
+		tbl = table.New(table.WithBorder(border.Set{}), table.WithPadding(0)).
+			AddRow("a"+color.FgRed.Wrap("b")+"c").
+			SetRowStyle(0, bg)
+	)
+
+	require.Equal(
+		t,
+		bg.String()+"a"+color.FgRed.String()+"b"+color.Reset.String()+bg.String()+"c"+
+			color.Reset.String()+"\n",
+		tbl.String(),
+	)
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package terminfo
+
+import (
+	"strconv"
+	"strings"
+
+	"go.mway.dev/color"
+)
+
+// _attrCaps map SGR attribute codes to the capabilities that enable them.
+var _attrCaps = map[int]string{
+	1: "bold",
+	2: "dim",
+	3: "sitm",
+	4: "smul",
+	5: "blink",
+	7: "rev",
+	8: "invis",
+	9: "smxx",
+}
+
+// _exitCaps map SGR codes that turn an attribute off to the capabilities that
+// do so.
+var _exitCaps = map[int]string{
+	23: "ritm",
+	24: "rmul",
+	29: "rmxx",
+}
+
+// Colors returns the number of colors the terminal supports, or 0 if it does
+// not support color.
+func (t *Terminfo) Colors() int {
+	n, _ := t.Num("colors")
+	return max(n, 0)
+}
+
+// TrueColor reports whether the terminal supports 24-bit color, as indicated
+// by the "Tc" or "RGB" extended capabilities or a direct-color "colors" value.
+func (t *Terminfo) TrueColor() bool {
+	return t.Bool("Tc") || t.Has("RGB") || t.Colors() >= 1<<24
+}
+
+// Depth returns the color depth the terminal supports.
+func (t *Terminfo) Depth() color.Depth {
+	switch n := t.Colors(); {
+	case t.TrueColor():
+		return color.DepthTrueColor
+	case n >= 256:
+		return color.Depth256
+	case n >= 8:
+		return color.Depth16
+	default:
+		return color.DepthNone
+	}
+}
+
+// Escape returns the escape sequences that apply style on the terminal, using
+// its capabilities rather than hard-coded ANSI sequences. Attributes the
+// terminal lacks are omitted, and colors beyond its depth are approximated by
+// the nearest color it supports (see [color.DefaultPalette]). Codes that turn
+// an attribute or color off use the terminal's dedicated capability if it has
+// one, and otherwise "op" or "sgr0", which may reset more than the code does.
+// Escape returns an empty string if color is disabled (see [color.Enabled]).
+func (t *Terminfo) Escape(style color.Style) string {
+	if style == nil || !color.Enabled() {
+		return ""
+	}
+
+	var (
+		buf   strings.Builder
+		codes = strings.Split(style.Code(), ";")
+	)
+
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			buf.WriteString(t.cap("sgr0"))
+		case code >= 22 && code <= 29 && code != 26, code == 39, code == 49:
+			buf.WriteString(t.reset(code))
+		case code == 38 || code == 48:
+			c, n, ok := color.ParseExtendedColor(codes[i+1:])
+			i += n
+			if ok {
+				buf.WriteString(t.setExtended(code == 38, c))
+			}
+		default:
+			buf.WriteString(t.basic(code))
+		}
+	}
+
+	return buf.String()
+}
+
+// Wrap wraps str with the escape sequences that apply style (see
+// [Terminfo.Escape]) and reset it.
+func (t *Terminfo) Wrap(style color.Style, str string) string {
+	esc := t.Escape(style)
+	if len(esc) == 0 {
+		return str
+	}
+	return esc + str + t.cap("sgr0")
+}
+
+// basic returns the escape sequence for a basic SGR attribute or color code.
+func (t *Terminfo) basic(code int) string {
+	if name, ok := _attrCaps[code]; ok {
+		return t.cap(name)
+	}
+
+	switch {
+	case code >= 30 && code <= 37:
+		return t.setColor("setaf", code-30)
+	case code >= 90 && code <= 97:
+		return t.bright(true, code-90)
+	case code >= 40 && code <= 47:
+		return t.setColor("setab", code-40)
+	case code >= 100 && code <= 107:
+		return t.bright(false, code-100)
+	default:
+		return ""
+	}
+}
+
+// reset returns the escape sequence for an SGR code that turns an attribute
+// or color off.
+func (t *Terminfo) reset(code int) string {
+	if name, ok := _exitCaps[code]; ok {
+		if str, ok := t.Str(name); ok {
+			return str
+		}
+	}
+	if code == 39 || code == 49 {
+		if str, ok := t.Str("op"); ok {
+			return str
+		}
+	}
+	return t.cap("sgr0")
+}
+
+// bright returns the escape sequence that sets the foreground (or background)
+// to the high-intensity variant of the basic color n, or to n itself if the
+// terminal has only 8 colors.
+func (t *Terminfo) bright(fg bool, n int) string {
+	name := "setab"
+	if fg {
+		name = "setaf"
+	}
+
+	switch colors := t.Colors(); {
+	case colors >= 1<<24:
+		// Direct-color terminals only index the 8 basic colors; any other
+		// value is read as packed RGB, so use the palette's RGB value.
+		return t.setExtended(fg, color.ExtendedColor{
+			Index: n + 8,
+			RGB:   color.DefaultPalette().Indexed(uint8(n + 8)),
+		})
+	case colors >= 16:
+		return t.setColor(name, n+8)
+	default:
+		return t.setColor(name, n)
+	}
+}
+
+// setExtended returns the escape sequence that sets the foreground (or
+// background) to the extended color c.
+func (t *Terminfo) setExtended(fg bool, c color.ExtendedColor) string {
+	name := "setab"
+	if fg {
+		name = "setaf"
+	}
+
+	switch n := t.Colors(); {
+	case n >= 1<<24:
+		// Direct-color terminals take RGB values in place of indexes.
+		return t.setColor(name, int(c.RGB.R)<<16|int(c.RGB.G)<<8|int(c.RGB.B))
+	case c.Index >= 0 && c.Index < n:
+		return t.setColor(name, c.Index)
+	case t.TrueColor():
+		if fg {
+			return c24(38, c.RGB)
+		}
+		return c24(48, c.RGB)
+	default:
+		return t.setColor(name, nearest(c.RGB, min(n, 256)))
+	}
+}
+
+// setColor returns the expansion of the color capability name for color n,
+// or an empty string if the terminal does not support it.
+func (t *Terminfo) setColor(name string, n int) string {
+	if n < 0 || n >= t.Colors() {
+		return ""
+	}
+
+	format, ok := t.Str(name)
+	if !ok {
+		return ""
+	}
+
+	str, err := Expand(format, n)
+	if err != nil {
+		return ""
+	}
+	return str
+}
+
+// cap returns the value of the string capability name, or an empty string if
+// it is not set.
+func (t *Terminfo) cap(name string) string {
+	str, _ := t.Str(name)
+	return str
+}
+
+// nearest returns the index of the color among the first n colors of the
+// 256-color palette that is perceptually closest to c.
+func nearest(c color.RGB, n int) int {
+	var (
+		palette = color.DefaultPalette()
+		lab     = c.OKLab()
+		best    = -1
+		dist    float64
+	)
+
+	for i := 0; i < n; i++ {
+		d := lab.Distance(palette.Indexed(uint8(i)).OKLab())
+		if best < 0 || d < dist {
+			best, dist = i, d
+		}
+	}
+
+	return best
+}
+
+// c24 returns the SGR sequence that sets a 24-bit color with the given code
+// (38 for foreground, 48 for background).
+func c24(code int, c color.RGB) string {
+	return "\x1b[" + strconv.Itoa(code) + ";2;" +
+		strconv.Itoa(int(c.R)) + ";" +
+		strconv.Itoa(int(c.G)) + ";" +
+		strconv.Itoa(int(c.B)) + "m"
+}
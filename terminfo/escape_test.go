@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package terminfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/terminfo"
+)
+
+func TestTerminfo_Depth(t *testing.T) {
+	cases := map[string]color.Depth{
+		"test-legacy":    color.Depth16,
+		"test-tc":        color.DepthTrueColor,
+		"xterm-256color": color.Depth256,
+		"xterm-direct":   color.DepthTrueColor,
+	}
+
+	for name, want := range cases {
+		require.Equal(t, want, open(t, name).Depth(), name)
+	}
+
+	require.Equal(t, color.DepthNone, (&terminfo.Terminfo{}).Depth())
+	require.Zero(t, (&terminfo.Terminfo{}).Colors())
+	require.Empty(t, (&terminfo.Terminfo{}).Name())
+}
+
+func TestTerminfo_Escape(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		orange = color.RGB{R: 255, G: 135}
+		sgr0   = "\x1b(B\x1b[m"
+	)
+
+	cases := []struct {
+		term  string
+		style color.Style
+		want  string
+	}{
+		{term: "xterm-256color", style: nil, want: ""},
+		{term: "xterm-256color", style: color.Nop, want: ""},
+		{term: "xterm-256color", style: color.FgRed, want: "\x1b[31m"},
+		{term: "xterm-256color", style: color.FgHiRed, want: "\x1b[91m"},
+		{term: "xterm-256color", style: color.BgHiBlue, want: "\x1b[104m"},
+		{term: "xterm-256color", style: color.Italic, want: "\x1b[3m"},
+		{term: "xterm-256color", style: color.Reset, want: sgr0},
+		{
+			term:  "xterm-256color",
+			style: color.Combine(color.Bold, color.Underline, color.Fg256(208)),
+			want:  "\x1b[1m\x1b[4m\x1b[38;5;208m",
+		},
+		{term: "xterm-256color", style: orange.Fg(), want: "\x1b[38;5;208m"},
+		{term: "xterm-256color", style: color.CrossedOut, want: "\x1b[9m"},
+		{term: "xterm-256color", style: color.SGR("\x1b[23;24;29m"), want: "\x1b[23m\x1b[24m\x1b[29m"},
+		{term: "xterm-256color", style: color.SGR("\x1b[22m"), want: sgr0},
+		{term: "xterm-256color", style: color.SGR("\x1b[39;1m"), want: "\x1b[39;49m\x1b[1m"},
+		{term: "xterm-256color", style: color.SGR("\x1b[49m"), want: "\x1b[39;49m"},
+		{term: "xterm-direct", style: color.FgRed, want: "\x1b[31m"},
+		{term: "xterm-direct", style: orange.Fg(), want: "\x1b[38:2::255:135:0m"},
+		{term: "xterm-direct", style: orange.Bg(), want: "\x1b[48:2::255:135:0m"},
+		{term: "xterm-direct", style: color.Fg256(67), want: "\x1b[38:2::95:135:175m"},
+		{term: "xterm-direct", style: color.FgHiRed, want: "\x1b[38:2::255:0:0m"},
+		{term: "xterm-direct", style: color.FgHiBlack, want: "\x1b[38:2::127:127:127m"},
+		{term: "xterm-direct", style: color.BgHiBlue, want: "\x1b[48:2::92:92:255m"},
+		{term: "xterm-direct", style: color.BgHiWhite, want: "\x1b[48:2::255:255:255m"},
+		{term: "test-tc", style: orange.Fg(), want: "\x1b[38;2;255;135;0m"},
+		{term: "test-tc", style: orange.Bg(), want: "\x1b[48;2;255;135;0m"},
+		{term: "test-tc", style: color.Fg256(208), want: "\x1b[38;5;208m"},
+		{term: "test-legacy", style: color.FgHiRed, want: "\x1b[31m"},
+		{term: "test-legacy", style: color.BgHiGreen, want: "\x1b[42m"},
+		{term: "test-legacy", style: color.Italic, want: ""},
+		{term: "test-legacy", style: color.SGR("\x1b[24m"), want: "\x1b[0m"},
+		{term: "test-legacy", style: color.SGR("\x1b[39m"), want: "\x1b[0m"},
+		{term: "test-legacy", style: color.Fg256(196), want: "\x1b[31m"},
+		{term: "test-legacy", style: orange.Fg(), want: "\x1b[33m"},
+		{term: "test-legacy", style: color.Fg256(231), want: "\x1b[37m"},
+	}
+
+	for _, tt := range cases {
+		ti := open(t, tt.term)
+		require.Equal(t, tt.want, ti.Escape(tt.style), "%s %q", tt.term, tt.want)
+	}
+
+	require.Empty(t, (&terminfo.Terminfo{}).Escape(color.FgRed))
+}
+
+func TestTerminfo_Escape_Disabled(t *testing.T) {
+	colortest.ForceColor(t, false)
+
+	ti := open(t, "xterm-256color")
+	require.Empty(t, ti.Escape(color.FgRed))
+	require.Empty(t, ti.Escape(color.Reset))
+	require.Equal(t, "x", ti.Wrap(color.Bold, "x"))
+}
+
+func TestTerminfo_Wrap(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		xterm  = open(t, "xterm-256color")
+		legacy = open(t, "test-legacy")
+	)
+
+	require.Equal(t, "\x1b[1mx\x1b(B\x1b[m", xterm.Wrap(color.Bold, "x"))
+	require.Equal(t, "x", xterm.Wrap(color.Nop, "x"))
+	require.Equal(t, "x", legacy.Wrap(color.Italic, "x"))
+	require.Equal(t, "\x1b[4mx\x1b[0m", legacy.Wrap(color.Underline, "x"))
+}
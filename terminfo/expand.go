@@ -0,0 +1,279 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package terminfo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mway.dev/errors"
+)
+
+// _binaryOps are the parameter operators that pop two values and push one.
+var _binaryOps = map[byte]func(a int, b int) int{
+	'+': func(a, b int) int { return a + b },
+	'-': func(a, b int) int { return a - b },
+	'*': func(a, b int) int { return a * b },
+	'/': func(a, b int) int {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	},
+	'm': func(a, b int) int {
+		if b == 0 {
+			return 0
+		}
+		return a % b
+	},
+	'&': func(a, b int) int { return a & b },
+	'|': func(a, b int) int { return a | b },
+	'^': func(a, b int) int { return a ^ b },
+	'=': func(a, b int) int { return boolInt(a == b) },
+	'>': func(a, b int) int { return boolInt(a > b) },
+	'<': func(a, b int) int { return boolInt(a < b) },
+	'A': func(a, b int) int { return boolInt(a != 0 && b != 0) },
+	'O': func(a, b int) int { return boolInt(a != 0 || b != 0) },
+}
+
+// Expand expands a parameterized capability string, such as the value of
+// "setaf", with the given parameters, as described in terminfo(5). String
+// parameters are not supported; %s and %l operate on numbers.
+func Expand(format string, params ...int) (string, error) {
+	m := machine{format: format}
+	copy(m.params[:], params)
+
+	for m.pos < len(m.format) {
+		c := m.format[m.pos]
+		m.pos++
+
+		if c != '%' {
+			m.out.WriteByte(c)
+			continue
+		}
+
+		if err := m.op(); err != nil {
+			return "", err
+		}
+	}
+
+	return m.out.String(), nil
+}
+
+// A machine is the state of a parameter expansion.
+type machine struct {
+	format string
+	pos    int
+	params [9]int
+	vars   [52]int
+	stack  []int
+	out    strings.Builder
+}
+
+// op executes the operator following a '%' at m.pos.
+func (m *machine) op() error {
+	if m.pos >= len(m.format) {
+		return errors.Wrap(ErrInvalid, "trailing percent sign")
+	}
+
+	c := m.format[m.pos]
+	m.pos++
+
+	if fn, ok := _binaryOps[c]; ok {
+		b, a := m.pop(), m.pop()
+		m.push(fn(a, b))
+		return nil
+	}
+
+	switch c {
+	case '%':
+		m.out.WriteByte('%')
+	case 'c':
+		m.out.WriteByte(byte(m.pop()))
+	case 'p', 'P', 'g':
+		return m.variable(c)
+	case '\'':
+		return m.char()
+	case '{':
+		return m.literal()
+	case 'l':
+		m.push(len(strconv.Itoa(m.pop())))
+	case '!':
+		m.push(boolInt(m.pop() == 0))
+	case '~':
+		m.push(^m.pop())
+	case 'i':
+		m.params[0]++
+		m.params[1]++
+	case '?', ';':
+	case 't':
+		if m.pop() == 0 {
+			m.skip(true)
+		}
+	case 'e':
+		m.skip(false)
+	default:
+		m.pos--
+		return m.printf()
+	}
+
+	return nil
+}
+
+// variable pushes a parameter (%p), or sets (%P) or pushes (%g) a variable.
+// Lowercase variables are dynamic and uppercase variables are static; both
+// last for the duration of the expansion.
+func (m *machine) variable(op byte) error {
+	if m.pos >= len(m.format) {
+		return errors.Wrap(ErrInvalid, fmt.Sprintf("missing operand for %q", op))
+	}
+
+	c := m.format[m.pos]
+	m.pos++
+
+	var idx int
+	switch {
+	case op == 'p' && c >= '1' && c <= '9':
+		m.push(m.params[c-'1'])
+		return nil
+	case op != 'p' && c >= 'a' && c <= 'z':
+		idx = int(c - 'a')
+	case op != 'p' && c >= 'A' && c <= 'Z':
+		idx = int(c-'A') + 26
+	default:
+		return errors.Wrap(ErrInvalid, fmt.Sprintf("bad operand %q for %q", c, op))
+	}
+
+	if op == 'P' {
+		m.vars[idx] = m.pop()
+	} else {
+		m.push(m.vars[idx])
+	}
+	return nil
+}
+
+// char pushes a character constant, e.g. %'x'.
+func (m *machine) char() error {
+	if m.pos+1 >= len(m.format) || m.format[m.pos+1] != '\'' {
+		return errors.Wrap(ErrInvalid, "unterminated character constant")
+	}
+
+	m.push(int(m.format[m.pos]))
+	m.pos += 2
+	return nil
+}
+
+// literal pushes an integer constant, e.g. %{10}.
+func (m *machine) literal() error {
+	end := strings.IndexByte(m.format[m.pos:], '}')
+	if end < 0 {
+		return errors.Wrap(ErrInvalid, "unterminated integer constant")
+	}
+
+	n, err := strconv.Atoi(m.format[m.pos : m.pos+end])
+	if err != nil {
+		return errors.Wrap(ErrInvalid, "bad integer constant")
+	}
+
+	m.push(n)
+	m.pos += end + 1
+	return nil
+}
+
+// printf pops a value and writes it formatted as with %[[:]flags][width
+// [.precision]][doxXs].
+func (m *machine) printf() error {
+	start := m.pos
+	if m.pos < len(m.format) && m.format[m.pos] == ':' {
+		m.pos++
+	}
+
+	for m.pos < len(m.format) && strings.IndexByte("-+# .0123456789", m.format[m.pos]) >= 0 {
+		m.pos++
+	}
+
+	if m.pos >= len(m.format) || strings.IndexByte("doxXs", m.format[m.pos]) < 0 {
+		return errors.Wrap(ErrInvalid, fmt.Sprintf("bad operator at offset %d", start-1))
+	}
+
+	var (
+		spec = strings.TrimPrefix(m.format[start:m.pos], ":")
+		verb = m.format[m.pos]
+	)
+
+	m.pos++
+	if verb == 's' {
+		verb = 'd'
+	}
+
+	fmt.Fprintf(&m.out, "%"+spec+string(verb), m.pop()) //nolint:errcheck
+	return nil
+}
+
+// skip advances past the current branch of a conditional: to just after the
+// matching %e (if else is true) or %;, whichever comes first.
+func (m *machine) skip(toElse bool) {
+	depth := 0
+	for m.pos < len(m.format)-1 {
+		if m.format[m.pos] != '%' {
+			m.pos++
+			continue
+		}
+
+		c := m.format[m.pos+1]
+		m.pos += 2
+
+		switch {
+		case c == '?':
+			depth++
+		case c == ';' && depth == 0:
+			return
+		case c == ';':
+			depth--
+		case c == 'e' && depth == 0 && toElse:
+			return
+		default:
+		}
+	}
+	m.pos = len(m.format)
+}
+
+func (m *machine) push(n int) {
+	m.stack = append(m.stack, n)
+}
+
+func (m *machine) pop() int {
+	if len(m.stack) == 0 {
+		return 0
+	}
+
+	n := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return n
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
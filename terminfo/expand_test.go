@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package terminfo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color/terminfo"
+)
+
+func TestExpand(t *testing.T) {
+	const setaf = "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m"
+
+	cases := []struct {
+		format string
+		params []int
+		want   string
+	}{
+		{format: "plain", want: "plain"},
+		{format: "%%", want: "%"},
+		{format: setaf, params: []int{1}, want: "\x1b[31m"},
+		{format: setaf, params: []int{9}, want: "\x1b[91m"},
+		{format: setaf, params: []int{208}, want: "\x1b[38;5;208m"},
+		{format: "\x1b[%i%p1%d;%p2%dH", params: []int{4, 9}, want: "\x1b[5;10H"},
+		{format: "%p1%p2%+%d", params: []int{2, 3}, want: "5"},
+		{format: "%p1%p2%-%d", params: []int{2, 3}, want: "-1"},
+		{format: "%p1%p2%*%d", params: []int{2, 3}, want: "6"},
+		{format: "%p1%p2%/%d", params: []int{7, 2}, want: "3"},
+		{format: "%p1%p2%/%d", params: []int{7, 0}, want: "0"},
+		{format: "%p1%p2%m%d", params: []int{7, 4}, want: "3"},
+		{format: "%p1%p2%m%d", params: []int{7, 0}, want: "0"},
+		{format: "%p1%{255}%&%d", params: []int{0x1234}, want: "52"},
+		{format: "%p1%{1}%|%d", params: []int{4}, want: "5"},
+		{format: "%p1%{1}%^%d", params: []int{5}, want: "4"},
+		{format: "%p1%{3}%=%d%p1%{3}%>%d", params: []int{3}, want: "10"},
+		{format: "%p1%p2%A%d%p1%p2%O%d", params: []int{1, 0}, want: "01"},
+		{format: "%p1%!%d%p1%~%d", params: []int{0}, want: "1-1"},
+		{format: "%'A'%c%p1%c", params: []int{'z'}, want: "Az"},
+		{format: "%p1%l%d", params: []int{12345}, want: "5"},
+		{format: "%p1%Pa%ga%ga%+%d", params: []int{21}, want: "42"},
+		{format: "%p1%PZ%gZ%d", params: []int{7}, want: "7"},
+		{format: "%p1%02x|%p1%X|%p1%o|%p1%:-4d|%p1%s", params: []int{10}, want: "0a|A|12|10  |10"},
+		{format: "%?%p1%t1%e%?%p2%t2%e3%;%;", params: []int{0, 1}, want: "2"},
+		{format: "%?%p1%t1%e%?%p2%t2%e3%;%;", params: []int{0, 0}, want: "3"},
+		{format: "%?%p1%t%?%p2%tA%eB%;%eC%;!", params: []int{1, 0}, want: "B!"},
+		{format: "%?%p1%tA%;!", params: []int{0}, want: "!"},
+		{format: "%?%p1%tA", params: []int{0}, want: ""},
+		{format: "%d%c", want: "0\x00"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := terminfo.Expand(tt.format, tt.params...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpand_Invalid(t *testing.T) {
+	for _, format := range []string{
+		"%",
+		"%p",
+		"%p0",
+		"%Pa%P1",
+		"%'a",
+		"%{12",
+		"%{x}",
+		"%z",
+		"%5",
+	} {
+		_, err := terminfo.Expand(format)
+		require.ErrorIs(t, err, terminfo.ErrInvalid, format)
+	}
+}
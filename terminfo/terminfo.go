@@ -0,0 +1,446 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package terminfo reads compiled terminfo entries, which describe the
+// capabilities of terminals and the escape sequences that control them.
+package terminfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.mway.dev/errors"
+)
+
+const (
+	_magicLegacy   = 0o432
+	_magicExtended = 0o1036
+	_headerSize    = 12
+)
+
+var (
+	// ErrNotFound is returned when no terminfo entry exists for a terminal.
+	ErrNotFound = errors.New("terminfo entry not found")
+	// ErrInvalid is returned when a terminfo entry cannot be parsed.
+	ErrInvalid = errors.New("invalid terminfo entry")
+
+	// _boolCaps, _numCaps, and _stringCaps map the names of commonly used
+	// standard capabilities to their indexes in compiled entries.
+	_boolCaps = map[string]int{
+		"am":  1,
+		"hs":  9,
+		"bce": 28,
+	}
+	_numCaps = map[string]int{
+		"cols":   0,
+		"lines":  2,
+		"colors": 13,
+		"pairs":  14,
+	}
+	_stringCaps = map[string]int{
+		"clear": 5,
+		"el":    6,
+		"cup":   10,
+		"cud1":  11,
+		"civis": 13,
+		"cub1":  14,
+		"cnorm": 16,
+		"cuf1":  17,
+		"cuu1":  19,
+		"blink": 26,
+		"bold":  27,
+		"smcup": 28,
+		"dim":   30,
+		"invis": 32,
+		"rev":   34,
+		"smso":  35,
+		"smul":  36,
+		"sgr0":  39,
+		"rmcup": 40,
+		"rmul":  44,
+		"fsl":   47,
+		"tsl":   135,
+		"op":    297,
+		"setf":  302,
+		"setb":  303,
+		"sitm":  311,
+		"ritm":  321,
+		"setaf": 359,
+		"setab": 360,
+	}
+)
+
+// A Terminfo is a parsed terminfo entry.
+type Terminfo struct {
+	// Names are the entry's names, e.g. "xterm-256color", followed by its
+	// description.
+	Names []string
+
+	bools   []bool
+	nums    []int
+	strings []string
+	ext     extended
+}
+
+// extended holds the entry's user-defined capabilities, such as "Tc" and
+// "RGB".
+type extended struct {
+	bools   map[string]bool
+	nums    map[string]int
+	strings map[string]string
+}
+
+// Load loads the terminfo entry for term, searching, in order, the directory
+// named by the TERMINFO environment variable, ~/.terminfo, the directories
+// named by TERMINFO_DIRS, /etc/terminfo, /lib/terminfo, and
+// /usr/share/terminfo. It returns an error wrapping [ErrNotFound] if no entry
+// exists.
+func Load(term string) (*Terminfo, error) {
+	if len(term) == 0 || strings.ContainsAny(term, "/\\") || strings.HasPrefix(term, ".") {
+		return nil, errors.Wrap(ErrNotFound, fmt.Sprintf("%q", term))
+	}
+
+	for _, dir := range searchDirs() {
+		for _, sub := range []string{term[:1], fmt.Sprintf("%02x", term[0])} {
+			path := filepath.Join(dir, sub, term)
+			if _, err := os.Stat(path); err == nil {
+				return Open(path)
+			}
+		}
+	}
+
+	return nil, errors.Wrap(ErrNotFound, term)
+}
+
+// LoadEnv loads the terminfo entry for the terminal named by the TERM
+// environment variable (see [Load]).
+func LoadEnv() (*Terminfo, error) {
+	return Load(os.Getenv("TERM"))
+}
+
+// Open reads and parses the compiled terminfo entry at path.
+func Open(path string) (*Terminfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses a compiled terminfo entry, in either the legacy format or the
+// extended number format introduced by ncurses 6.1, including any extended
+// capabilities.
+func Parse(data []byte) (*Terminfo, error) {
+	r := reader{data: data}
+
+	var (
+		magic     = r.int16()
+		nameSize  = r.int16()
+		boolCount = r.int16()
+		numCount  = r.int16()
+		strCount  = r.int16()
+		tableSize = r.int16()
+		numSize   int
+	)
+
+	switch magic {
+	case _magicLegacy:
+		numSize = 2
+	case _magicExtended:
+		numSize = 4
+	default:
+		return nil, errors.Wrap(ErrInvalid, fmt.Sprintf("bad magic %#o", magic))
+	}
+
+	if r.short || nameSize < 0 || boolCount < 0 || numCount < 0 ||
+		strCount < 0 || tableSize < 0 {
+		return nil, errors.Wrap(ErrInvalid, "bad header")
+	}
+
+	t := &Terminfo{
+		Names: strings.Split(string(bytes.TrimRight(r.bytes(nameSize), "\x00")), "|"),
+	}
+
+	t.bools = r.bools(boolCount)
+	r.align()
+	t.nums = r.nums(numCount, numSize)
+	offsets := r.offsets(strCount)
+	t.strings, _ = strTable(r.bytes(tableSize), offsets)
+
+	if r.short {
+		return nil, errors.Wrap(ErrInvalid, "truncated")
+	}
+
+	r.align()
+	if r.remaining() > 0 {
+		ext, err := parseExtended(&r, numSize)
+		if err != nil {
+			return nil, err
+		}
+		t.ext = ext
+	}
+
+	return t, nil
+}
+
+// parseExtended parses the extended capabilities section that follows the
+// standard capabilities.
+func parseExtended(r *reader, numSize int) (extended, error) {
+	var (
+		boolCount = r.int16()
+		numCount  = r.int16()
+		strCount  = r.int16()
+		_         = r.int16() // total number of table entries
+		tableSize = r.int16()
+	)
+
+	if r.short || boolCount < 0 || numCount < 0 || strCount < 0 || tableSize < 0 {
+		return extended{}, errors.Wrap(ErrInvalid, "bad extended header")
+	}
+
+	bools := r.bools(boolCount)
+	r.align()
+
+	var (
+		nums        = r.nums(numCount, numSize)
+		offsets     = r.offsets(strCount)
+		nameOffsets = r.offsets(boolCount + numCount + strCount)
+		table       = r.bytes(tableSize)
+	)
+
+	if r.short {
+		return extended{}, errors.Wrap(ErrInvalid, "truncated extended capabilities")
+	}
+
+	values, end := strTable(table, offsets)
+	names, _ := strTable(table[min(end, len(table)):], nameOffsets)
+
+	ext := extended{
+		bools:   make(map[string]bool, boolCount),
+		nums:    make(map[string]int, numCount),
+		strings: make(map[string]string, strCount),
+	}
+
+	for i, name := range names {
+		switch {
+		case i < boolCount:
+			ext.bools[name] = bools[i]
+		case i < boolCount+numCount:
+			if n := nums[i-boolCount]; n >= 0 {
+				ext.nums[name] = n
+			}
+		default:
+			if value := values[i-boolCount-numCount]; len(value) > 0 {
+				ext.strings[name] = value
+			}
+		}
+	}
+
+	return ext, nil
+}
+
+// Name returns the entry's primary name.
+func (t *Terminfo) Name() string {
+	if len(t.Names) == 0 {
+		return ""
+	}
+	return t.Names[0]
+}
+
+// Bool returns whether the boolean capability name, such as "am" or the
+// extended "Tc", is set.
+func (t *Terminfo) Bool(name string) bool {
+	if idx, ok := _boolCaps[name]; ok && idx < len(t.bools) {
+		return t.bools[idx]
+	}
+	return t.ext.bools[name]
+}
+
+// Num returns the value of the numeric capability name, such as "colors",
+// and whether it is set.
+func (t *Terminfo) Num(name string) (int, bool) {
+	if idx, ok := _numCaps[name]; ok {
+		if idx < len(t.nums) && t.nums[idx] >= 0 {
+			return t.nums[idx], true
+		}
+		return 0, false
+	}
+
+	n, ok := t.ext.nums[name]
+	return n, ok
+}
+
+// Str returns the value of the string capability name, such as "setaf", and
+// whether it is set. Parameterized values can be expanded with [Expand].
+func (t *Terminfo) Str(name string) (string, bool) {
+	if idx, ok := _stringCaps[name]; ok {
+		if idx < len(t.strings) && len(t.strings[idx]) > 0 {
+			return t.strings[idx], true
+		}
+		return "", false
+	}
+
+	str, ok := t.ext.strings[name]
+	return str, ok
+}
+
+// Has reports whether the capability name is set, whatever its type. This is
+// useful for capabilities such as "RGB", which terminals define as booleans,
+// numbers, or strings.
+func (t *Terminfo) Has(name string) bool {
+	_, num := t.Num(name)
+	_, str := t.Str(name)
+	return t.Bool(name) || num || str
+}
+
+// searchDirs returns the directories that terminfo entries are loaded from.
+func searchDirs() []string {
+	var dirs []string
+
+	if dir := os.Getenv("TERMINFO"); len(dir) > 0 {
+		dirs = append(dirs, dir)
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+
+	defaults := []string{"/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo"}
+	if env := os.Getenv("TERMINFO_DIRS"); len(env) > 0 {
+		for _, dir := range filepath.SplitList(env) {
+			if len(dir) == 0 {
+				dirs = append(dirs, defaults...)
+			} else {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+
+	return append(dirs, defaults...)
+}
+
+// strTable returns the NUL-terminated strings at offsets in table, with
+// negative offsets yielding empty strings, and the offset just past the end
+// of the last string.
+func strTable(table []byte, offsets []int) ([]string, int) {
+	var (
+		strs = make([]string, len(offsets))
+		end  int
+	)
+
+	for i, off := range offsets {
+		if off < 0 || off >= len(table) {
+			continue
+		}
+
+		n := bytes.IndexByte(table[off:], 0)
+		if n < 0 {
+			n = len(table) - off
+		}
+
+		strs[i] = string(table[off : off+n])
+		end = max(end, off+n+1)
+	}
+
+	return strs, end
+}
+
+// A reader reads little-endian values from a compiled entry. Reads past the
+// end of the data set short and return zero values.
+type reader struct {
+	data  []byte
+	off   int
+	short bool
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.short {
+		return nil
+	}
+	if n < 0 || r.off+n > len(r.data) {
+		r.short = true
+		return nil
+	}
+
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *reader) int16() int {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return int(int16(binary.LittleEndian.Uint16(b)))
+}
+
+func (r *reader) int32() int {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return int(int32(binary.LittleEndian.Uint32(b)))
+}
+
+func (r *reader) bools(n int) []bool {
+	b := r.bytes(n)
+	dst := make([]bool, len(b))
+	for i := range b {
+		dst[i] = b[i] == 1
+	}
+	return dst
+}
+
+func (r *reader) nums(n int, size int) []int {
+	dst := make([]int, n)
+	for i := range dst {
+		if size == 4 {
+			dst[i] = r.int32()
+		} else {
+			dst[i] = r.int16()
+		}
+	}
+	return dst
+}
+
+func (r *reader) offsets(n int) []int {
+	dst := make([]int, n)
+	for i := range dst {
+		dst[i] = r.int16()
+	}
+	return dst
+}
+
+// align advances r to an even offset, as entries pad sections to 16-bit
+// boundaries.
+func (r *reader) align() {
+	if r.off%2 == 1 && r.off < len(r.data) {
+		r.off++
+	}
+}
+
+func (r *reader) remaining() int {
+	return len(r.data) - r.off
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package terminfo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color/terminfo"
+)
+
+// The fixtures in testdata were compiled by ncurses 6.5: t/* from
+// testdata/test.src with "tic -x -o testdata testdata/test.src", which uses
+// the legacy format, and x/* from its distribution, which use the extended
+// number format.
+
+func open(t *testing.T, name string) *terminfo.Terminfo {
+	ti, err := terminfo.Open(filepath.Join("testdata", name[:1], name))
+	require.NoError(t, err)
+	return ti
+}
+
+func TestOpen_Legacy(t *testing.T) {
+	ti := open(t, "test-legacy")
+
+	require.Equal(t, "test-legacy", ti.Name())
+	require.Equal(t, []string{"test-legacy", "legacy format test terminal"}, ti.Names)
+	require.True(t, ti.Bool("am"))
+	require.False(t, ti.Bool("bce"))
+	require.False(t, ti.Bool("Tc"))
+
+	n, ok := ti.Num("colors")
+	require.True(t, ok)
+	require.Equal(t, 8, n)
+
+	n, ok = ti.Num("cols")
+	require.True(t, ok)
+	require.Equal(t, 80, n)
+
+	_, ok = ti.Num("lines")
+	require.False(t, ok)
+
+	str, ok := ti.Str("setaf")
+	require.True(t, ok)
+	require.Equal(t, "\x1b[3%p1%dm", str)
+
+	str, ok = ti.Str("smul")
+	require.True(t, ok)
+	require.Equal(t, "\x1b[4m", str)
+
+	_, ok = ti.Str("sitm")
+	require.False(t, ok)
+	_, ok = ti.Str("unknown")
+	require.False(t, ok)
+}
+
+func TestOpen_LegacyExtended(t *testing.T) {
+	ti := open(t, "test-tc")
+
+	require.True(t, ti.Bool("Tc"))
+	require.True(t, ti.Has("Tc"))
+	require.False(t, ti.Has("RGB"))
+
+	n, _ := ti.Num("colors")
+	require.Equal(t, 256, n)
+
+	str, _ := ti.Str("sitm")
+	require.Equal(t, "\x1b[3m", str)
+}
+
+func TestOpen_ExtendedNumbers(t *testing.T) {
+	data, err := os.ReadFile("testdata/x/xterm-direct")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x1e, 0x02}, data[:2])
+
+	ti, err := terminfo.Parse(data)
+	require.NoError(t, err)
+
+	require.Equal(t, "xterm-direct", ti.Name())
+	n, ok := ti.Num("colors")
+	require.True(t, ok)
+	require.Equal(t, 1<<24, n)
+
+	require.True(t, ti.Bool("RGB"))
+	require.True(t, ti.Has("RGB"))
+	require.True(t, ti.Bool("am"))
+
+	n, ok = ti.Num("CO")
+	require.True(t, ok)
+	require.Equal(t, 8, n)
+
+	str, ok := ti.Str("setaf")
+	require.True(t, ok)
+	require.Contains(t, str, "38:2::")
+}
+
+func TestOpen_Xterm256(t *testing.T) {
+	ti := open(t, "xterm-256color")
+
+	n, _ := ti.Num("colors")
+	require.Equal(t, 256, n)
+	require.False(t, ti.Has("RGB"))
+	require.True(t, ti.Bool("AX"))
+	require.True(t, ti.Has("XT"))
+
+	for name, want := range map[string]string{
+		"setaf": "\x1b[%?%p1%{8}%<%t3%p1%d%e%p1%{16}%<%t9%p1%{8}%-%d%e38;5;%p1%d%;m",
+		"sitm":  "\x1b[3m",
+		"smul":  "\x1b[4m",
+		"sgr0":  "\x1b(B\x1b[m",
+		"E3":    "\x1b[3J",
+		"Ms":    "\x1b]52;%p1%s;%p2%s\a",
+	} {
+		str, ok := ti.Str(name)
+		require.True(t, ok, name)
+		require.Equal(t, want, str, name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TERMINFO_DIRS", "")
+
+	t.Setenv("TERMINFO", "testdata")
+	ti, err := terminfo.Load("test-legacy")
+	require.NoError(t, err)
+	require.Equal(t, "test-legacy", ti.Name())
+
+	t.Setenv("TERM", "test-tc")
+	ti, err = terminfo.LoadEnv()
+	require.NoError(t, err)
+	require.Equal(t, "test-tc", ti.Name())
+
+	// Entries may be stored in directories named by the hex value of the
+	// first character.
+	t.Setenv("TERMINFO", "testdata/hashed")
+	ti, err = terminfo.Load("test-legacy")
+	require.NoError(t, err)
+	require.Equal(t, "test-legacy", ti.Name())
+
+	t.Setenv("TERMINFO", "")
+	t.Setenv("TERMINFO_DIRS", "testdata/missing:testdata")
+	_, err = terminfo.Load("xterm-direct")
+	require.NoError(t, err)
+
+	// ~/.terminfo is searched.
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".terminfo", "t"), 0o755))
+	data, err := os.ReadFile("testdata/t/test-tc")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".terminfo", "t", "test-home"), data, 0o644))
+	t.Setenv("HOME", home)
+	ti, err = terminfo.Load("test-home")
+	require.NoError(t, err)
+	require.True(t, ti.Bool("Tc"))
+
+	for _, term := range []string{"", "../t/test-legacy", "t/test-legacy", ".x", "no-such-term"} {
+		_, err = terminfo.Load(term)
+		require.ErrorIs(t, err, terminfo.ErrNotFound, term)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	data, err := os.ReadFile("testdata/t/test-tc")
+	require.NoError(t, err)
+
+	cases := map[string][]byte{
+		"empty":     nil,
+		"magic":     {0x00, 0x00, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		"header":    data[:8],
+		"truncated": data[:40],
+		"extended":  data[:len(data)-20],
+		"negative":  append([]byte{0x1a, 0x01, 0xff, 0xff}, data[4:]...),
+	}
+
+	for name, data := range cases {
+		_, err := terminfo.Parse(data)
+		require.ErrorIs(t, err, terminfo.ErrInvalid, name)
+	}
+
+	_, err = terminfo.Open("testdata/missing")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"io"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// A Span is a run of text rendered with a single [Style].
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Text is a sequence of styled [Span]s. Unlike the strings returned by
+// [Style.Sprint], a Text can be further edited (appended to, sliced, split)
+// before it is rendered. The zero value is an empty Text.
+type Text struct {
+	spans []Span
+	// end is shared by every Text whose spans share a backing array, and is
+	// the length of the longest of them. Only a Text of that length may
+	// append to the array in place, so that appending is amortized O(1)
+	// without changing the spans seen by other Texts.
+	end *atomic.Int64
+}
+
+// NewText returns a [Text] containing the given spans. Spans with empty text
+// are omitted, and spans with a nil style are treated as [Nop].
+func NewText(spans ...Span) Text {
+	var t Text
+	for _, span := range spans {
+		t = t.Append(span.Text, span.Style)
+	}
+	return t
+}
+
+// Append returns a new [Text] with str, styled with style, appended to t.
+func (t Text) Append(str string, style Style) Text {
+	if len(str) == 0 {
+		return t
+	}
+	if style == nil {
+		style = Nop
+	}
+
+	n := len(t.spans)
+	if n > 0 && sameStyle(t.spans[n-1].Style, style) {
+		// Merging changes the last span, which t shares, so copy the spans.
+		spans := append([]Span(nil), t.spans...)
+		spans[n-1].Text += str
+		return newText(spans)
+	}
+
+	span := Span{Text: str, Style: style}
+	if t.end != nil && n < cap(t.spans) && t.end.CompareAndSwap(int64(n), int64(n+1)) {
+		return Text{spans: append(t.spans, span), end: t.end}
+	}
+	return newText(append(t.spans[:n:n], span))
+}
+
+// newText returns a Text that owns spans' backing array.
+func newText(spans []Span) Text {
+	end := new(atomic.Int64)
+	end.Store(int64(len(spans)))
+	return Text{spans: spans, end: end}
+}
+
+// Concat returns a new [Text] with each of others appended to t.
+func (t Text) Concat(others ...Text) Text {
+	for _, other := range others {
+		for _, span := range other.spans {
+			t = t.Append(span.Text, span.Style)
+		}
+	}
+	return t
+}
+
+// Spans returns a copy of t's spans.
+func (t Text) Spans() []Span {
+	return append([]Span(nil), t.spans...)
+}
+
+// Len returns the number of terminal cells that t occupies when rendered.
+func (t Text) Len() int {
+	var n int
+	for _, span := range t.spans {
+		n += plainWidth(span.Text)
+	}
+	return n
+}
+
+// Slice returns the portion of t that occupies the cells in [start, end). Wide
+// runes that straddle either boundary are omitted, as are zero-width runes
+// (such as combining marks) that belong to a cell before start.
+func (t Text) Slice(start, end int) Text {
+	var (
+		dst     Text
+		col     int
+		started bool
+	)
+
+	for _, span := range t.spans {
+		lo, hi := -1, -1
+		for i, r := range span.Text {
+			w := RuneWidth(r)
+			if col >= start && col+w <= end && (w > 0 || started || col == 0) {
+				if lo < 0 {
+					lo = i
+				}
+				hi = i + utf8.RuneLen(r)
+				started = true
+			}
+			col += w
+		}
+
+		if lo >= 0 {
+			dst = dst.Append(span.Text[lo:hi], span.Style)
+		}
+		if col > end {
+			break
+		}
+	}
+
+	return dst
+}
+
+// Split slices t into all substrings separated by sep, as in
+// [strings.Split]. Styles are preserved across the returned values, and sep
+// may span multiple spans.
+func (t Text) Split(sep string) []Text {
+	var (
+		plain = t.Plain()
+		dst   []Text
+		prev  int
+	)
+
+	if len(sep) == 0 {
+		for i, r := range plain {
+			dst = append(dst, t.byteSlice(i, i+utf8.RuneLen(r)))
+		}
+		return dst
+	}
+
+	for {
+		idx := strings.Index(plain[prev:], sep)
+		if idx < 0 {
+			break
+		}
+		dst = append(dst, t.byteSlice(prev, prev+idx))
+		prev += idx + len(sep)
+	}
+
+	return append(dst, t.byteSlice(prev, len(plain)))
+}
+
+// Plain returns t's text without any styling.
+func (t Text) Plain() string {
+	var buf strings.Builder
+	for _, span := range t.spans {
+		buf.WriteString(span.Text)
+	}
+	return buf.String()
+}
+
+// String returns t rendered with each span wrapped in its style.
+func (t Text) String() string {
+	buf := _builders.Get()
+	defer _builders.Put(buf)
+
+	t.WriteTo(buf) //nolint:errcheck
+	return buf.String()
+}
+
+// WriteTo writes t, rendered with each span wrapped in its style, to w.
+func (t Text) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, span := range t.spans {
+		n, err := io.WriteString(w, span.Style.Wrap(span.Text))
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (t Text) byteSlice(start, end int) Text {
+	var (
+		dst Text
+		off int
+	)
+
+	for _, span := range t.spans {
+		lo := max(start-off, 0)
+		hi := min(end-off, len(span.Text))
+		if lo < hi {
+			dst = dst.Append(span.Text[lo:hi], span.Style)
+		}
+		off += len(span.Text)
+		if off >= end {
+			break
+		}
+	}
+
+	return dst
+}
+
+func sameStyle(a Style, b Style) bool {
+	if x, ok := a.(Color); ok {
+		y, ok := b.(Color)
+		return ok && x == y
+	}
+	return a.String() == b.String()
+}
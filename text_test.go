@@ -0,0 +1,162 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestText(t *testing.T) {
+	text := NewText(
+		Span{Text: "foo", Style: FgRed},
+		Span{Text: "bar", Style: FgRed},
+		Span{Text: ""},
+		Span{Text: "baz"},
+	)
+
+	require.Equal(t, []Span{
+		{Text: "foobar", Style: FgRed},
+		{Text: "baz", Style: Nop},
+	}, text.Spans())
+	require.Equal(t, 9, text.Len())
+	require.Equal(t, "foobarbaz", text.Plain())
+	require.Equal(t, FgRed.Wrap("foobar")+"baz", text.String())
+
+	var buf bytes.Buffer
+	n, err := text.WriteTo(&buf)
+	require.NoError(t, err)
+	require.EqualValues(t, buf.Len(), n)
+	require.Equal(t, text.String(), buf.String())
+}
+
+func TestText_Append(t *testing.T) {
+	var (
+		base = NewText().Append("a", FgRed)
+		x    = base.Append("b", FgBlue)
+		y    = base.Append("c", Bold)
+	)
+
+	require.Equal(t, FgRed.Wrap("a")+FgBlue.Wrap("b"), x.String())
+	require.Equal(t, FgRed.Wrap("a")+Bold.Wrap("c"), y.String())
+	require.Equal(t, FgRed.Wrap("a"), base.String())
+
+	z := x.Concat(y, NewText(Span{Text: "d", Style: Bold}))
+	require.Equal(t, "abacd", z.Plain())
+	require.Len(t, z.Spans(), 4)
+
+	// Appending in place must not change texts that share the same spans.
+	var long Text
+	for i := 0; i < 100; i++ {
+		long = long.Append("x", []Style{FgRed, FgBlue}[i%2])
+	}
+	var (
+		first  = long.Append("1", Bold)
+		second = long.Append("2", Italic)
+		merged = long.Append("3", FgBlue)
+	)
+	require.Len(t, long.Spans(), 100)
+	require.Equal(t, strings.Repeat("x", 100)+"1", first.Plain())
+	require.Equal(t, strings.Repeat("x", 100)+"2", second.Plain())
+	require.Equal(t, strings.Repeat("x", 100)+"3", merged.Plain())
+	require.Equal(t, strings.Repeat("x", 100), long.Plain())
+	require.Equal(t, Bold, first.Spans()[100].Style)
+	require.Equal(t, Italic, second.Spans()[100].Style)
+}
+
+func TestText_Slice(t *testing.T) {
+	text := NewText(
+		Span{Text: "ab", Style: FgRed},
+		Span{Text: "世界", Style: FgBlue},
+		Span{Text: "cd", Style: Bold},
+	)
+
+	require.Equal(t, 8, text.Len())
+
+	cases := []struct {
+		start int
+		end   int
+		want  string
+	}{
+		{start: 0, end: 8, want: text.String()},
+		{start: 0, end: 2, want: FgRed.Wrap("ab")},
+		{start: 1, end: 4, want: FgRed.Wrap("b") + FgBlue.Wrap("世")},
+		{start: 1, end: 5, want: FgRed.Wrap("b") + FgBlue.Wrap("世")},
+		{start: 3, end: 7, want: FgBlue.Wrap("界") + Bold.Wrap("c")},
+		{start: 6, end: 100, want: Bold.Wrap("cd")},
+		{start: 8, end: 10, want: ""},
+	}
+
+	for _, tt := range cases {
+		require.Equal(t, tt.want, text.Slice(tt.start, tt.end).String())
+	}
+
+	// Combining marks belong to the cell before them.
+	accents := NewText(Span{Text: "e\u0301a\u0300", Style: Nop}, Span{Text: "\u0302b", Style: Bold})
+	require.Equal(t, "e\u0301", accents.Slice(0, 1).Plain())
+	require.Equal(t, "a\u0300\u0302", accents.Slice(1, 2).Plain())
+	require.Equal(t, "b", accents.Slice(2, 3).Plain())
+	require.Equal(t, "", accents.Slice(3, 4).Plain())
+}
+
+func TestText_Split(t *testing.T) {
+	text := NewText(
+		Span{Text: "a,b", Style: FgRed},
+		Span{Text: ",c", Style: FgBlue},
+		Span{Text: "::d", Style: Bold},
+	)
+
+	var got []string
+	for _, part := range text.Split(",") {
+		got = append(got, part.String())
+	}
+	require.Equal(t, []string{
+		FgRed.Wrap("a"),
+		FgRed.Wrap("b"),
+		FgBlue.Wrap("c") + Bold.Wrap("::d"),
+	}, got)
+
+	parts := text.Split("c:")
+	require.Len(t, parts, 2)
+	require.Equal(t, "a,b,", parts[0].Plain())
+	require.Equal(t, ":d", parts[1].Plain())
+
+	require.Len(t, NewText(Span{Text: "世界"}).Split(""), 2)
+}
+
+func TestRuneWidth(t *testing.T) {
+	cases := map[rune]int{
+		'a':      1,
+		'\t':     0,
+		'\u0301': 0,
+		'\u200d': 0,
+		'世':      2,
+		'😀':      2,
+		'é':      1,
+	}
+
+	for r, want := range cases {
+		require.Equal(t, want, RuneWidth(r), "%q", r)
+	}
+}
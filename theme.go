@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"os"
+)
+
+// CVDEnv is the environment variable that selects a color vision
+// deficiency-safe [Theme] (see [ThemeFromEnv]). Its value is parsed with
+// [ParseDeficiency], e.g. COLOR_CVD=deutan.
+const CVDEnv = "COLOR_CVD"
+
+// A Theme assigns styles to common semantic roles, so that output can adapt
+// to its reader without changing the code that produces it.
+type Theme struct {
+	// Error is applied to errors and failures.
+	Error Style
+	// Warning is applied to warnings.
+	Warning Style
+	// Success is applied to successes.
+	Success Style
+	// Info is applied to informational highlights.
+	Info Style
+	// Muted is applied to secondary text.
+	Muted Style
+	// Added is applied to additions, e.g. in diffs.
+	Added Style
+	// Removed is applied to removals, e.g. in diffs.
+	Removed Style
+}
+
+// DefaultTheme returns the default [Theme], which uses the basic colors.
+func DefaultTheme() Theme {
+	return Theme{
+		Error:   FgRed,
+		Warning: FgYellow,
+		Success: FgGreen,
+		Info:    FgCyan,
+		Muted:   FgHiBlack,
+		Added:   FgGreen,
+		Removed: FgRed,
+	}
+}
+
+// CVDTheme returns a [Theme] whose roles remain distinguishable to someone
+// with the given color vision deficiency. Errors are also bold, so that they
+// do not rely on color alone. The themes use the 256-color palette.
+func CVDTheme(d Deficiency) Theme {
+	switch d {
+	case Protanopia, Deuteranopia:
+		// Blue and orange remain distinct without red or green cones.
+		return Theme{
+			Error:   Fg256(166).With(Bold),
+			Warning: Fg256(226),
+			Success: Fg256(81),
+			Info:    Fg256(63),
+			Muted:   FgHiBlack,
+			Added:   Fg256(81),
+			Removed: Fg256(166),
+		}
+	case Tritanopia:
+		// Red, pink, and greens remain distinct without blue cones.
+		return Theme{
+			Error:   Fg256(196).With(Bold),
+			Warning: Fg256(218),
+			Success: Fg256(49),
+			Info:    Fg256(29),
+			Muted:   FgHiBlack,
+			Added:   Fg256(49),
+			Removed: Fg256(196),
+		}
+	default:
+		return DefaultTheme()
+	}
+}
+
+// ThemeFromEnv returns the [CVDTheme] for the deficiency named by the
+// [CVDEnv] environment variable, or [DefaultTheme] if it is unset or invalid.
+func ThemeFromEnv() Theme {
+	d, err := ParseDeficiency(os.Getenv(CVDEnv))
+	if err != nil {
+		return DefaultTheme()
+	}
+	return CVDTheme(d)
+}
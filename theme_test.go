@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCVDTheme(t *testing.T) {
+	var (
+		p  = DefaultPalette()
+		bg = _black
+	)
+
+	require.Equal(t, DefaultTheme(), CVDTheme(NoDeficiency))
+	require.Equal(t, DefaultTheme(), CVDTheme(Deficiency(-1)))
+
+	for _, d := range Deficiencies() {
+		t.Run(d.String(), func(t *testing.T) {
+			theme := CVDTheme(d)
+
+			require.Empty(t, d.Conflicts(
+				p,
+				theme.Error,
+				theme.Warning,
+				theme.Success,
+				theme.Info,
+			))
+			require.Empty(t, d.Conflicts(p, theme.Added, theme.Removed))
+
+			for _, style := range []Style{
+				theme.Error,
+				theme.Warning,
+				theme.Success,
+				theme.Info,
+				theme.Added,
+				theme.Removed,
+			} {
+				fg, ok := p.Foreground(style)
+				require.True(t, ok)
+				require.GreaterOrEqual(t, Contrast(d.Simulate(fg), bg), WCAGAALarge.Ratio())
+			}
+		})
+	}
+
+	// The default theme is not safe.
+	theme := DefaultTheme()
+	require.NotEmpty(t, p.CVDConflicts(theme.Added, theme.Removed))
+}
+
+func TestThemeFromEnv(t *testing.T) {
+	t.Setenv(CVDEnv, "")
+	require.Equal(t, DefaultTheme(), ThemeFromEnv())
+
+	t.Setenv(CVDEnv, "deutan")
+	require.Equal(t, CVDTheme(Deuteranopia), ThemeFromEnv())
+
+	t.Setenv(CVDEnv, "Tritanopia")
+	require.Equal(t, CVDTheme(Tritanopia), ThemeFromEnv())
+
+	t.Setenv(CVDEnv, "bogus")
+	require.Equal(t, DefaultTheme(), ThemeFromEnv())
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter  = Value{}
+	_ fmt.Stringer   = Value{}
+	_ slog.LogValuer = Value{}
+)
+
+// A Value is a value that is styled when it is formatted. Unlike the strings
+// returned by [Style.Sprint], a Value can be passed directly to format
+// strings: width is applied to its visible text rather than its escaped
+// bytes, so that, for example,
+//
+//	fmt.Sprintf("%-10s|", color.NewValue(color.FgRed, "ok"))
+//
+// pads "ok" to ten columns. Padding is written outside of the style. Other
+// flags, precision, and the verb are applied to the underlying value.
+type Value struct {
+	v     any
+	style Style
+}
+
+// NewValue returns a [Value] that formats v styled with style. A nil style is
+// treated as [Nop].
+func NewValue(style Style, v any) Value {
+	if style == nil {
+		style = Nop
+	}
+	return Value{v: v, style: style}
+}
+
+// Unwrap returns the underlying value.
+func (v Value) Unwrap() any {
+	return v.v
+}
+
+// Style returns the style applied to the value.
+func (v Value) Style() Style {
+	if v.style == nil {
+		return Nop
+	}
+	return v.style
+}
+
+// String returns the value formatted as with [fmt.Sprint] and styled. Like
+// [Style.Wrap], the value is only styled if color is enabled.
+func (v Value) String() string {
+	return v.Style().Wrap(fmt.Sprint(v.v))
+}
+
+// LogValue returns the underlying value, unstyled, so that structured log
+// handlers receive plain data.
+func (v Value) LogValue() slog.Value {
+	return slog.AnyValue(v.v)
+}
+
+// Format implements [fmt.Formatter].
+func (v Value) Format(f fmt.State, verb rune) {
+	var (
+		spec      strings.Builder
+		width, ok = f.Width()
+		zero      = f.Flag('0') && !f.Flag('-')
+	)
+
+	spec.WriteByte('%')
+	for _, flag := range "+# " {
+		if f.Flag(int(flag)) {
+			spec.WriteRune(flag)
+		}
+	}
+	if zero && ok {
+		// Zero padding is part of the value (e.g. a number's digits), so it is
+		// styled along with it.
+		spec.WriteByte('0')
+		spec.WriteString(strconv.Itoa(width))
+	}
+	if prec, ok := f.Precision(); ok {
+		spec.WriteByte('.')
+		spec.WriteString(strconv.Itoa(prec))
+	}
+	spec.WriteRune(verb)
+
+	var (
+		str = v.Style().Wrap(fmt.Sprintf(spec.String(), v.v))
+		pad string
+	)
+
+	if n := width - VisibleWidth(str); ok && !zero && n > 0 {
+		pad = strings.Repeat(" ", n)
+	}
+
+	if f.Flag('-') {
+		io.WriteString(f, str+pad) //nolint:errcheck
+	} else {
+		io.WriteString(f, pad+str) //nolint:errcheck
+	}
+}
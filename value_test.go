@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue_Format(t *testing.T) {
+	red := func(str string) string {
+		return FgRed.String() + str + Reset.String()
+	}
+
+	cases := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{format: "%s", value: "ok", want: red("ok")},
+		{format: "%-6s|", value: "ok", want: red("ok") + "    |"},
+		{format: "%6s|", value: "ok", want: "    " + red("ok") + "|"},
+		{format: "%6s|", value: "世界", want: "  " + red("世界") + "|"},
+		{format: "%.2s", value: "hello", want: red("he")},
+		{format: "%-6.3s|", value: "hello", want: red("hel") + "   |"},
+		{format: "%05d", value: 42, want: red("00042")},
+		{format: "%-05d|", value: 42, want: red("42") + "   |"},
+		{format: "%+d", value: 42, want: red("+42")},
+		{format: "%8.2f|", value: 3.14159, want: "    " + red("3.14") + "|"},
+		{format: "%x", value: "hi", want: red("6869")},
+		{format: "%#v", value: "hi", want: red(`"hi"`)},
+		{format: "%q", value: "hi", want: red(`"hi"`)},
+		{format: "%v", value: []int{1, 2}, want: red("[1 2]")},
+		{format: "%1s", value: "long", want: red("long")},
+		{
+			format: "%-8v|",
+			value:  NewValue(Bold, "ab"),
+			want:   red(Bold.String()+"ab"+Reset.String()) + "      |",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(t, tt.want, fmt.Sprintf(tt.format, NewValue(FgRed, tt.value)))
+		})
+	}
+}
+
+func TestValue_Disabled(t *testing.T) {
+	t.Cleanup(OverrideEnabled(false))
+
+	v := NewValue(FgRed, "ok")
+	require.Equal(t, "ok   |", fmt.Sprintf("%-5s|", v))
+	require.Equal(t, "ok", v.String())
+}
+
+func TestValue(t *testing.T) {
+	v := NewValue(nil, 42)
+	require.Equal(t, Nop, v.Style())
+	require.Equal(t, 42, v.Unwrap())
+	require.Equal(t, "42", v.String())
+	require.Equal(t, Nop, Value{}.Style())
+
+	v = NewValue(FgRed, 42)
+	require.Equal(t, FgRed, v.Style())
+	require.Equal(t, FgRed.Wrap("42"), v.String())
+	require.Equal(t, slog.Int64Value(42), v.LogValue().Resolve())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+	logger.Info("msg", "v", NewValue(FgRed, "plain"))
+	require.Equal(t, "level=INFO msg=msg v=plain\n", buf.String())
+}
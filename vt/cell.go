@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package vt
+
+import (
+	"go.mway.dev/color"
+)
+
+// An Attr is a set of text attributes, such as bold or underline.
+type Attr uint16
+
+// Text attributes, which correspond to the attribute [color.Color]s.
+const (
+	// AttrBold is [color.Bold].
+	AttrBold Attr = 1 << iota
+	// AttrFaint is [color.Faint].
+	AttrFaint
+	// AttrItalic is [color.Italic].
+	AttrItalic
+	// AttrUnderline is [color.Underline].
+	AttrUnderline
+	// AttrBlinkSlow is [color.BlinkSlow].
+	AttrBlinkSlow
+	// AttrBlinkRapid is [color.BlinkRapid].
+	AttrBlinkRapid
+	// AttrReverseVideo is [color.ReverseVideo].
+	AttrReverseVideo
+	// AttrConcealed is [color.Concealed].
+	AttrConcealed
+	// AttrCrossedOut is [color.CrossedOut].
+	AttrCrossedOut
+)
+
+// _numAttrs is the number of text attributes.
+const _numAttrs = 9
+
+// Has reports whether a includes all of the attributes in attrs.
+func (a Attr) Has(attrs Attr) bool {
+	return a&attrs == attrs
+}
+
+// A Cell is a single character cell of a [Screen].
+type Cell struct {
+	// Rune is the character in the cell, or 0 if the cell is empty or is
+	// covered by a wide character in the previous cell.
+	Rune rune
+	// Attrs are the cell's text attributes.
+	Attrs Attr
+	// Fg is the cell's foreground color, e.g. [color.FgRed],
+	// [color.Fg256], or [color.RGB.Fg], or nil for the default foreground.
+	Fg color.Style
+	// Bg is the cell's background color, e.g. [color.BgRed],
+	// [color.Bg256], or [color.RGB.Bg], or nil for the default background.
+	Bg color.Style
+
+	// wide is set for the cell after a wide character.
+	wide bool
+}
+
+// Style returns the combination of c's attributes and colors, which is
+// [color.Nop] if c is unstyled.
+func (c Cell) Style() color.Style {
+	var styles []color.Style
+	for i := 0; i < _numAttrs; i++ {
+		if c.Attrs.Has(1 << i) {
+			styles = append(styles, color.Bold+color.Color(i))
+		}
+	}
+	if c.Fg != nil {
+		styles = append(styles, c.Fg)
+	}
+	if c.Bg != nil {
+		styles = append(styles, c.Bg)
+	}
+
+	switch len(styles) {
+	case 0:
+		return color.Nop
+	case 1:
+		return styles[0]
+	default:
+		return color.Combine(styles...)
+	}
+}
+
+// sameStyle reports whether c and other have the same attributes and colors.
+func (c Cell) sameStyle(other Cell) bool {
+	return c.Attrs == other.Attrs && c.Fg == other.Fg && c.Bg == other.Bg
+}
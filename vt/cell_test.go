@@ -0,0 +1,67 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package vt_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/vt"
+)
+
+func TestAttr_Has(t *testing.T) {
+	attrs := vt.AttrBold | vt.AttrUnderline
+	require.True(t, attrs.Has(vt.AttrBold))
+	require.True(t, attrs.Has(vt.AttrBold|vt.AttrUnderline))
+	require.False(t, attrs.Has(vt.AttrItalic))
+	require.False(t, attrs.Has(vt.AttrBold|vt.AttrItalic))
+}
+
+func TestCell_Style(t *testing.T) {
+	cases := map[string]struct {
+		cell vt.Cell
+		want color.Style
+	}{
+		"empty": {
+			cell: vt.Cell{Rune: 'a'},
+			want: color.Nop,
+		},
+		"fg": {
+			cell: vt.Cell{Fg: color.FgRed},
+			want: color.FgRed,
+		},
+		"attrs": {
+			cell: vt.Cell{Attrs: vt.AttrBold | vt.AttrCrossedOut},
+			want: color.Combine(color.Bold, color.CrossedOut),
+		},
+		"all": {
+			cell: vt.Cell{Attrs: vt.AttrItalic, Fg: color.Fg256(208), Bg: color.RGB{B: 255}.Bg()},
+			want: color.Combine(color.Italic, color.Fg256(208), color.RGB{B: 255}.Bg()),
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want.String(), tt.cell.Style().String())
+		})
+	}
+}
@@ -0,0 +1,566 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+// Package vt provides an in-memory virtual terminal, which interprets the
+// escape sequences written to it so that tests can assert on what would
+// actually be displayed.
+package vt
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.mway.dev/color"
+)
+
+const _tabWidth = 8
+
+// A Screen is an in-memory terminal screen of fixed size. It interprets text,
+// SGR sequences, cursor movement and erase sequences, carriage returns, line
+// feeds, backspaces, and tabs, wrapping lines that reach its right edge and
+// scrolling lines that move past its bottom edge into its scrollback. Other
+// sequences, such as OSC sequences, are ignored.
+//
+// As with a terminal's default line discipline, line feeds also return the
+// cursor to the start of the line. A Screen is not safe for concurrent use.
+type Screen struct {
+	width      int
+	height     int
+	cells      [][]Cell
+	scrollback [][]Cell
+	row        int
+	col        int
+	savedRow   int
+	savedCol   int
+	pen        Cell
+	pending    []byte
+}
+
+// New creates a new, empty [Screen] with the given number of columns and
+// rows, each of which must be at least 1.
+func New(width int, height int) *Screen {
+	s := &Screen{
+		width:  max(width, 1),
+		height: max(height, 1),
+	}
+	s.cells = make([][]Cell, s.height)
+	for i := range s.cells {
+		s.cells[i] = make([]Cell, s.width)
+	}
+	return s
+}
+
+// Size returns the number of columns and rows of s.
+func (s *Screen) Size() (int, int) {
+	return s.width, s.height
+}
+
+// Cursor returns the cursor's row and column, counting from 0.
+func (s *Screen) Cursor() (int, int) {
+	return s.row, min(s.col, s.width-1)
+}
+
+// Write interprets p. Escape sequences and UTF-8 characters that are split
+// across writes are buffered until they are complete. It never returns an
+// error.
+func (s *Screen) Write(p []byte) (int, error) {
+	s.pending = append(s.pending, p...)
+
+	buf := s.pending
+	for len(buf) > 0 {
+		n := s.consume(buf)
+		if n == 0 {
+			break
+		}
+		buf = buf[n:]
+	}
+	s.pending = append(s.pending[:0], buf...)
+
+	return len(p), nil
+}
+
+// WriteString interprets str as [Screen.Write] does.
+func (s *Screen) WriteString(str string) (int, error) {
+	return s.Write([]byte(str))
+}
+
+// Cell returns the cell at row and col, counting from 0, or an empty cell if
+// row or col are out of bounds.
+func (s *Screen) Cell(row int, col int) Cell {
+	if row < 0 || row >= s.height || col < 0 || col >= s.width {
+		return Cell{}
+	}
+	return s.cells[row][col]
+}
+
+// Cells returns a copy of the cells of each row of s.
+func (s *Screen) Cells() [][]Cell {
+	cells := make([][]Cell, len(s.cells))
+	for i, row := range s.cells {
+		cells[i] = append([]Cell(nil), row...)
+	}
+	return cells
+}
+
+// Lines returns the plain text of each row of s, without trailing spaces.
+func (s *Screen) Lines() []string {
+	return plainLines(s.cells)
+}
+
+// Text returns the plain text of s, with rows separated by newlines and
+// without trailing spaces or empty rows.
+func (s *Screen) Text() string {
+	return joinLines(s.Lines())
+}
+
+// Scrollback returns the plain text of each line that has scrolled off the
+// top of s, oldest first, without trailing spaces.
+func (s *Screen) Scrollback() []string {
+	return plainLines(s.scrollback)
+}
+
+// Styled returns the text of s as [Screen.Text] does, with SGR sequences
+// that reproduce each cell's style, which can be checked with e.g.
+// colortest.Render. Blank cells with a visible background are kept, and each
+// row that ends styled is reset.
+func (s *Screen) Styled() string {
+	lines := make([]string, len(s.cells))
+	for i, row := range s.cells {
+		var (
+			buf   strings.Builder
+			pen   Cell
+			end   = trimmedLen(row, true)
+			reset = color.Reset.String()
+		)
+
+		for _, cell := range row[:end] {
+			if cell.wide {
+				continue
+			}
+			if !cell.sameStyle(pen) {
+				if !pen.sameStyle(Cell{}) {
+					buf.WriteString(reset)
+				}
+				buf.WriteString(cell.Style().String())
+				pen = cell
+			}
+			buf.WriteRune(runeOrSpace(cell.Rune))
+		}
+		if !pen.sameStyle(Cell{}) {
+			buf.WriteString(reset)
+		}
+		lines[i] = buf.String()
+	}
+	return joinLines(lines)
+}
+
+// consume interprets the character or escape sequence at the start of buf,
+// returning its length, or 0 if it is incomplete.
+func (s *Screen) consume(buf []byte) int {
+	switch c := buf[0]; {
+	case c == 0x1b:
+		n, ok := color.EscapeLen(buf)
+		if !ok {
+			return 0
+		}
+		s.escape(string(buf[:n]))
+		return n
+	case c < 0x20 || c == 0x7f:
+		s.control(c)
+		return 1
+	default:
+		if !utf8.FullRune(buf) {
+			return 0
+		}
+		r, n := utf8.DecodeRune(buf)
+		s.print(r)
+		return n
+	}
+}
+
+// control interprets the control character c.
+func (s *Screen) control(c byte) {
+	switch c {
+	case '\r':
+		s.col = 0
+	case '\n', '\v', '\f':
+		s.col = 0
+		s.lineFeed()
+	case '\b':
+		s.col = max(min(s.col, s.width-1)-1, 0)
+	case '\t':
+		s.col = min((s.col/_tabWidth+1)*_tabWidth, s.width-1)
+	default:
+	}
+}
+
+// print writes r at the cursor and advances it, wrapping first if r does not
+// fit on the current line.
+func (s *Screen) print(r rune) {
+	width := color.RuneWidth(r)
+	if width == 0 {
+		return
+	}
+
+	if s.col+width > s.width {
+		s.col = 0
+		s.lineFeed()
+	}
+
+	line := s.cells[s.row]
+	s.clearWide(line, s.col)
+	cell := s.pen
+	cell.Rune = r
+	line[s.col] = cell
+
+	if width == 2 && s.col+1 < s.width {
+		s.clearWide(line, s.col+1)
+		cont := s.pen
+		cont.wide = true
+		line[s.col+1] = cont
+	}
+
+	// The cursor may be left just past the right edge, in which case the
+	// next character wraps.
+	s.col += width
+}
+
+// clearWide clears the other half of a wide character that is partly
+// overwritten at col.
+func (s *Screen) clearWide(line []Cell, col int) {
+	switch {
+	case line[col].wide && col > 0:
+		line[col-1] = Cell{}
+	case col+1 < len(line) && line[col+1].wide:
+		line[col+1] = Cell{}
+	default:
+	}
+}
+
+// lineFeed moves the cursor down a row, scrolling if it is on the last row.
+func (s *Screen) lineFeed() {
+	if s.row < s.height-1 {
+		s.row++
+		return
+	}
+
+	s.scrollback = append(s.scrollback, s.cells[0])
+	copy(s.cells, s.cells[1:])
+	s.cells[s.height-1] = make([]Cell, s.width)
+}
+
+// reverseIndex moves the cursor up a row, scrolling down if it is on the
+// first row.
+func (s *Screen) reverseIndex() {
+	if s.row > 0 {
+		s.row--
+		return
+	}
+
+	copy(s.cells[1:], s.cells)
+	s.cells[0] = make([]Cell, s.width)
+}
+
+// escape interprets the complete escape sequence seq.
+func (s *Screen) escape(seq string) {
+	if len(seq) < 2 {
+		return
+	}
+
+	switch seq[1] {
+	case '[':
+		s.csi(seq[2:len(seq)-1], seq[len(seq)-1])
+	case '7':
+		s.savedRow, s.savedCol = s.row, s.col
+	case '8':
+		s.moveTo(s.savedRow, s.savedCol)
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.col = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		*s = *New(s.width, s.height)
+	default:
+	}
+}
+
+// csi interprets a CSI sequence with the given parameters and final byte.
+func (s *Screen) csi(params string, final byte) { //nolint:gocyclo
+	if strings.HasPrefix(params, "?") || strings.HasPrefix(params, ">") {
+		// Private modes, such as cursor visibility, do not affect the
+		// contents of the screen.
+		return
+	}
+
+	var (
+		args = parseParams(params)
+		n    = max(arg(args, 0, 1), 1)
+	)
+
+	switch final {
+	case 'A':
+		s.moveTo(s.row-n, s.col)
+	case 'B':
+		s.moveTo(s.row+n, s.col)
+	case 'C':
+		s.moveTo(s.row, s.col+n)
+	case 'D':
+		s.moveTo(s.row, min(s.col, s.width-1)-n)
+	case 'E':
+		s.moveTo(s.row+n, 0)
+	case 'F':
+		s.moveTo(s.row-n, 0)
+	case 'G', '`':
+		s.moveTo(s.row, n-1)
+	case 'd':
+		s.moveTo(n-1, s.col)
+	case 'H', 'f':
+		s.moveTo(max(arg(args, 0, 1), 1)-1, max(arg(args, 1, 1), 1)-1)
+	case 'J':
+		s.eraseDisplay(arg(args, 0, 0))
+	case 'K':
+		s.eraseLine(arg(args, 0, 0))
+	case 'S':
+		for i := 0; i < n; i++ {
+			row := s.row
+			s.row = s.height - 1
+			s.lineFeed()
+			s.row = row
+		}
+	case 'T':
+		for i := 0; i < n; i++ {
+			row := s.row
+			s.row = 0
+			s.reverseIndex()
+			s.row = row
+		}
+	case 's':
+		s.savedRow, s.savedCol = s.row, s.col
+	case 'u':
+		s.moveTo(s.savedRow, s.savedCol)
+	case 'm':
+		s.sgr(args)
+	default:
+	}
+}
+
+// moveTo moves the cursor to row and col, clamped to the screen.
+func (s *Screen) moveTo(row int, col int) {
+	s.row = min(max(row, 0), s.height-1)
+	s.col = min(max(col, 0), s.width-1)
+}
+
+// eraseDisplay erases part of the screen (ED).
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for _, line := range s.cells[s.row+1:] {
+			clear(line)
+		}
+	case 1:
+		s.eraseLine(1)
+		for _, line := range s.cells[:s.row] {
+			clear(line)
+		}
+	case 2:
+		for _, line := range s.cells {
+			clear(line)
+		}
+	case 3:
+		s.scrollback = nil
+	default:
+	}
+}
+
+// eraseLine erases part of the cursor's row (EL).
+func (s *Screen) eraseLine(mode int) {
+	var (
+		line = s.cells[s.row]
+		col  = min(s.col, s.width-1)
+	)
+
+	switch mode {
+	case 0:
+		clear(line[col:])
+	case 1:
+		clear(line[:col+1])
+	case 2:
+		clear(line)
+	default:
+	}
+}
+
+// sgr applies the SGR parameters args to the pen.
+func (s *Screen) sgr(args []int) { //nolint:gocyclo
+	if len(args) == 0 {
+		args = []int{0}
+	}
+
+	for i := 0; i < len(args); i++ {
+		switch code := args[i]; {
+		case code == 0:
+			s.pen = Cell{}
+		case code >= 1 && code <= _numAttrs:
+			s.pen.Attrs |= 1 << (code - 1)
+		case code == 22:
+			s.pen.Attrs &^= AttrBold | AttrFaint
+		case code == 23:
+			s.pen.Attrs &^= AttrItalic
+		case code == 24:
+			s.pen.Attrs &^= AttrUnderline
+		case code == 25:
+			s.pen.Attrs &^= AttrBlinkSlow | AttrBlinkRapid
+		case code == 27:
+			s.pen.Attrs &^= AttrReverseVideo
+		case code == 28:
+			s.pen.Attrs &^= AttrConcealed
+		case code == 29:
+			s.pen.Attrs &^= AttrCrossedOut
+		case code >= 30 && code <= 37, code >= 90 && code <= 97:
+			s.pen.Fg = color.Color(code)
+		case code >= 40 && code <= 47, code >= 100 && code <= 107:
+			s.pen.Bg = color.Color(code)
+		case code == 39:
+			s.pen.Fg = nil
+		case code == 49:
+			s.pen.Bg = nil
+		case code == 38, code == 48:
+			style, n := extended(code == 38, args[i+1:])
+			i += n
+			if style == nil {
+				continue
+			}
+			if code == 38 {
+				s.pen.Fg = style
+			} else {
+				s.pen.Bg = style
+			}
+		default:
+		}
+	}
+}
+
+// extended parses the parameters of an extended color (SGR 38 or 48),
+// returning the color and the number of parameters consumed.
+func extended(fg bool, args []int) (color.Style, int) {
+	switch {
+	case len(args) >= 2 && args[0] == 5:
+		n := uint8(min(max(args[1], 0), 255))
+		if fg {
+			return color.Fg256(n), 2
+		}
+		return color.Bg256(n), 2
+	case len(args) >= 4 && args[0] == 2:
+		rgb := color.RGB{
+			R: uint8(min(max(args[1], 0), 255)),
+			G: uint8(min(max(args[2], 0), 255)),
+			B: uint8(min(max(args[3], 0), 255)),
+		}
+		if fg {
+			return rgb.Fg(), 4
+		}
+		return rgb.Bg(), 4
+	default:
+		return nil, len(args)
+	}
+}
+
+// parseParams parses the semicolon- or colon-separated parameters of a CSI
+// sequence. Empty parameters are -1, so that they take their default value.
+func parseParams(params string) []int {
+	if len(params) == 0 {
+		return nil
+	}
+
+	fields := strings.FieldsFunc(params, func(r rune) bool {
+		return r == ';' || r == ':'
+	})
+	if strings.HasSuffix(params, ";") {
+		fields = append(fields, "")
+	}
+
+	args := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			n = -1
+		}
+		args[i] = n
+	}
+	return args
+}
+
+// arg returns args[i], or def if it is absent or empty.
+func arg(args []int, i int, def int) int {
+	if i >= len(args) || args[i] < 0 {
+		return def
+	}
+	return args[i]
+}
+
+func plainLines(rows [][]Cell) []string {
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		var buf strings.Builder
+		for _, cell := range row[:trimmedLen(row, false)] {
+			if !cell.wide {
+				buf.WriteRune(runeOrSpace(cell.Rune))
+			}
+		}
+		lines[i] = buf.String()
+	}
+	return lines
+}
+
+// trimmedLen returns the number of cells in row up to its last non-blank
+// cell. If styled is true, blank cells with a visible background are not
+// considered blank.
+func trimmedLen(row []Cell, styled bool) int {
+	n := len(row)
+	for ; n > 0; n-- {
+		cell := row[n-1]
+		if cell.wide || (cell.Rune != 0 && cell.Rune != ' ') {
+			break
+		}
+		if styled && (cell.Bg != nil || cell.Attrs.Has(AttrReverseVideo)) {
+			break
+		}
+	}
+	return n
+}
+
+func joinLines(lines []string) string {
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runeOrSpace(r rune) rune {
+	if r == 0 {
+		return ' '
+	}
+	return r
+}
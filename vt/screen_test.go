@@ -0,0 +1,281 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package vt_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mway.dev/color"
+	"go.mway.dev/color/colortest"
+	"go.mway.dev/color/cursor"
+	"go.mway.dev/color/status"
+	"go.mway.dev/color/vt"
+)
+
+func TestScreen_Text(t *testing.T) {
+	cases := map[string]struct {
+		width  int
+		height int
+		give   string
+		want   string
+		row    int
+		col    int
+	}{
+		"plain": {
+			give: "hello\nworld",
+			want: "hello\nworld",
+			row:  1,
+			col:  5,
+		},
+		"carriage return": {
+			give: "hello\rJ",
+			want: "Jello",
+			col:  1,
+		},
+		"wrap": {
+			width: 4,
+			give:  "abcdefghij",
+			want:  "abcd\nefgh\nij",
+			row:   2,
+			col:   2,
+		},
+		"deferred wrap": {
+			width: 4,
+			give:  "abcd\r\n",
+			want:  "abcd",
+			row:   1,
+		},
+		"wide": {
+			width: 5,
+			give:  "ab世界",
+			want:  "ab世\n界",
+			row:   1,
+			col:   2,
+		},
+		"overwrite wide": {
+			give: "世界\rx",
+			want: "x 界",
+			col:  1,
+		},
+		"backspace tab": {
+			give: "ab\bc\td",
+			want: "ac      d",
+			col:  9,
+		},
+		"cursor": {
+			give: "line1\nline2\nline3" + cursor.Up(2).String() + cursor.Column(3).String() + "X" +
+				cursor.Position(3, 1).String() + "Y",
+			want: "liXe1\nline2\nYine3",
+			row:  2,
+			col:  1,
+		},
+		"cursor clamp": {
+			width:  5,
+			height: 2,
+			give:   cursor.Down(10).String() + cursor.Forward(10).String() + "Z",
+			want:   "\n    Z",
+			row:    1,
+			col:    4,
+		},
+		"save restore": {
+			give: "a" + cursor.Save.String() + "\nb" + cursor.Restore.String() + "c",
+			want: "ac\nb",
+			col:  2,
+		},
+		"erase line": {
+			give: "hello" + cursor.Back(2).String() + cursor.EraseLineRight.String(),
+			want: "hel",
+			col:  3,
+		},
+		"erase line left": {
+			give: "hello" + cursor.Back(2).String() + cursor.EraseLineLeft.String(),
+			want: "    o",
+			col:  3,
+		},
+		"erase screen": {
+			give: "a\nb\nc" + cursor.Up(1).String() + cursor.EraseDown.String(),
+			want: "a\nb",
+			row:  1,
+			col:  1,
+		},
+		"erase all": {
+			give: "a\nb" + cursor.EraseScreen.String(),
+			want: "",
+			row:  1,
+			col:  1,
+		},
+		"scroll": {
+			height: 2,
+			give:   "1\n2\n3",
+			want:   "2\n3",
+			row:    1,
+			col:    1,
+		},
+		"redraw": {
+			give: "\r50%" + cursor.EraseLineRight.String() + "\r100%" + cursor.EraseLineRight.String(),
+			want: "100%",
+			col:  4,
+		},
+		"ignored": {
+			give: "\x1b]0;title\a\x1b[?25la\x1bPq\x1b\\\a",
+			want: "a",
+			col:  1,
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				width  = tt.width
+				height = tt.height
+			)
+			if width == 0 {
+				width = 20
+			}
+			if height == 0 {
+				height = 5
+			}
+
+			screen := vt.New(width, height)
+			n, err := screen.WriteString(tt.give)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.give), n)
+			require.Equal(t, tt.want, screen.Text())
+
+			row, col := screen.Cursor()
+			require.Equal(t, tt.row, row, "row")
+			require.Equal(t, tt.col, min(col, width-1), "col")
+		})
+	}
+}
+
+func TestScreen_Cells(t *testing.T) {
+	screen := vt.New(10, 2)
+	screen.WriteString( //nolint:errcheck
+		"a" + color.FgRed.With(color.Bold).String() + "b" +
+			"\x1b[22;4;48;5;17mc" + "\x1b[38;2;1;2;3;24;49md" + color.Reset.String() + "e",
+	)
+
+	require.Equal(t, vt.Cell{Rune: 'a'}, screen.Cell(0, 0))
+	require.Equal(t, vt.Cell{Rune: 'b', Attrs: vt.AttrBold, Fg: color.FgRed}, screen.Cell(0, 1))
+	require.Equal(
+		t,
+		vt.Cell{Rune: 'c', Attrs: vt.AttrUnderline, Fg: color.FgRed, Bg: color.Bg256(17)},
+		screen.Cell(0, 2),
+	)
+	require.Equal(t, vt.Cell{Rune: 'd', Fg: color.RGB{R: 1, G: 2, B: 3}.Fg()}, screen.Cell(0, 3))
+	require.Equal(t, vt.Cell{Rune: 'e'}, screen.Cell(0, 4))
+	require.Equal(t, vt.Cell{}, screen.Cell(5, 5))
+
+	cells := screen.Cells()
+	require.Len(t, cells, 2)
+	require.Len(t, cells[0], 10)
+	cells[0][0].Rune = 'z'
+	require.Equal(t, 'a', screen.Cell(0, 0).Rune)
+
+	width, height := screen.Size()
+	require.Equal(t, 10, width)
+	require.Equal(t, 2, height)
+}
+
+func TestScreen_Styled(t *testing.T) {
+	screen := vt.New(10, 3)
+	screen.WriteString( //nolint:errcheck
+		"a" + color.FgRed.String() + "bc" + color.Reset.String() + "d\n" +
+			color.BgBlue.String() + "  " + color.Reset.String() + "   \n" +
+			color.Bold.String() + "x",
+	)
+
+	require.Equal(
+		t,
+		"a"+color.FgRed.String()+"bc"+color.Reset.String()+"d\n"+
+			color.BgBlue.String()+"  "+color.Reset.String()+"\n"+
+			color.Bold.String()+"x"+color.Reset.String(),
+		screen.Styled(),
+	)
+	require.Equal(t, "abcd\n\nx", screen.Text())
+}
+
+func TestScreen_Scrollback(t *testing.T) {
+	screen := vt.New(10, 2)
+	screen.WriteString("one\ntwo\nthree\nfour") //nolint:errcheck
+
+	require.Equal(t, []string{"one", "two"}, screen.Scrollback())
+	require.Equal(t, []string{"three", "four"}, screen.Lines())
+
+	screen.WriteString("\x1b[3J") //nolint:errcheck
+	require.Empty(t, screen.Scrollback())
+}
+
+func TestScreen_SplitWrites(t *testing.T) {
+	var (
+		screen = vt.New(10, 2)
+		str    = "a" + color.FgGreen.String() + "é" + cursor.Column(1).String() + "b"
+	)
+
+	for i := 0; i < len(str); i++ {
+		screen.Write([]byte{str[i]}) //nolint:errcheck
+	}
+
+	require.Equal(t, "bé", screen.Text())
+	require.Equal(t, color.FgGreen, screen.Cell(0, 1).Fg)
+}
+
+func TestScreen_Reset(t *testing.T) {
+	screen := vt.New(10, 2)
+	screen.WriteString(color.FgRed.String() + "a\x1bcb") //nolint:errcheck
+
+	require.Equal(t, "b", screen.Text())
+	require.Nil(t, screen.Cell(0, 0).Fg)
+}
+
+func TestScreen_StatusRegion(t *testing.T) {
+	colortest.ForceColor(t, true)
+
+	var (
+		screen = vt.New(30, 6)
+		region = status.New(
+			screen,
+			status.WithMode(status.ModeInteractive),
+			status.WithRefreshInterval(time.Nanosecond),
+			status.WithWidth(29),
+		)
+	)
+
+	build := region.Add("build")
+	test := region.Add("test")
+	build.Set(status.StateRunning, "compiling")
+	region.Println("starting")
+	build.Set(status.StateSucceeded, "")
+	test.Set(status.StateRunning, strings.Repeat("x", 50))
+	region.Println("done")
+	region.Refresh()
+
+	require.Equal(
+		t,
+		"starting\ndone\n✓ build\n● test "+strings.Repeat("x", 21)+"…",
+		screen.Text(),
+	)
+	require.Equal(t, color.FgGreen, screen.Cell(2, 0).Fg)
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WrapOptions configure [WordWrap] and [HardWrap].
+type WrapOptions struct {
+	// Indent is prepended to each continuation line produced by wrapping.
+	Indent string
+}
+
+// DefaultWrapOptions returns a new [WrapOptions] with default values.
+func DefaultWrapOptions() WrapOptions {
+	return WrapOptions{}
+}
+
+// With returns a new [WrapOptions] based on o with the given options applied.
+func (o WrapOptions) With(opts ...WrapOption) WrapOptions {
+	for _, opt := range opts {
+		opt.apply(&o)
+	}
+	return o
+}
+
+func (o WrapOptions) apply(dst *WrapOptions) {
+	if len(o.Indent) > 0 {
+		dst.Indent = o.Indent
+	}
+}
+
+// A WrapOption configures [WordWrap] and [HardWrap].
+type WrapOption interface {
+	apply(*WrapOptions)
+}
+
+type wrapOptionFunc func(*WrapOptions)
+
+func (f wrapOptionFunc) apply(o *WrapOptions) {
+	f(o)
+}
+
+// WithIndent returns a [WrapOption] that prepends indent to each continuation
+// line.
+func WithIndent(indent string) WrapOption {
+	return wrapOptionFunc(func(o *WrapOptions) {
+		o.Indent = indent
+	})
+}
+
+// WordWrap wraps str so that no line exceeds width visible cells, breaking
+// lines between words where possible. Escape sequences do not count towards
+// width, and any style that is active at a line break is closed at the end of
+// the line and re-opened at the start of the next one. Words longer than width
+// are broken. A width <= 0 disables wrapping.
+func WordWrap(str string, width int, opts ...WrapOption) string {
+	if width <= 0 {
+		return str
+	}
+
+	w := newLineWriter(width, DefaultWrapOptions().With(opts...))
+	w.wordWrap(str)
+	return strings.Join(w.finish(), "\n")
+}
+
+// HardWrap wraps str so that no line exceeds width visible cells, breaking
+// lines at exactly width cells regardless of word boundaries. Styles are
+// handled as in [WordWrap]. A width <= 0 disables wrapping.
+func HardWrap(str string, width int, opts ...WrapOption) string {
+	if width <= 0 {
+		return str
+	}
+
+	w := newLineWriter(width, DefaultWrapOptions().With(opts...))
+	w.hardWrap(str)
+	return strings.Join(w.finish(), "\n")
+}
+
+// SplitLines splits str into lines, closing any style that is active at the
+// end of each line and re-opening it at the start of the next, so that each
+// line can be printed independently.
+func SplitLines(str string) []string {
+	w := newLineWriter(0, WrapOptions{})
+	w.hardWrap(str)
+	return w.finish()
+}
+
+type wrapToken struct {
+	text  string
+	width int
+}
+
+type lineWriter struct {
+	opts        WrapOptions
+	width       int
+	indentWidth int
+	start       int
+	col         int
+	state       sgrState
+	buf         strings.Builder
+	lines       []string
+}
+
+func newLineWriter(width int, opts WrapOptions) *lineWriter {
+	return &lineWriter{
+		opts:        opts,
+		width:       width,
+		indentWidth: VisibleWidth(opts.Indent),
+	}
+}
+
+func (w *lineWriter) wordWrap(str string) {
+	var (
+		word       []wrapToken
+		wordWidth  int
+		spaces     int
+		flushWords = func() {
+			spaces = w.writeWord(spaces, word, wordWidth)
+			word, wordWidth = word[:0], 0
+		}
+	)
+
+	for i := 0; i < len(str); {
+		switch c := str[i]; c {
+		case _esc:
+			n := escapeLen(str[i:])
+			word = append(word, wrapToken{text: str[i : i+n]})
+			i += n
+		case ' ':
+			flushWords()
+			spaces++
+			i++
+		case '\n':
+			flushWords()
+			spaces = 0
+			w.breakLine(false)
+			i++
+		default:
+			r, n := utf8.DecodeRuneInString(str[i:])
+			rw := RuneWidth(r)
+			word = append(word, wrapToken{text: str[i : i+n], width: rw})
+			wordWidth += rw
+			i += n
+		}
+	}
+
+	flushWords()
+}
+
+// writeWord writes word, preceded by the given number of spaces, breaking the
+// current line first if the word does not fit. It returns the number of
+// spaces still pending.
+func (w *lineWriter) writeWord(spaces int, word []wrapToken, wordWidth int) int {
+	if len(word) == 0 {
+		return spaces
+	}
+
+	if wordWidth == 0 {
+		// Escapes that are not attached to any text do not consume the
+		// pending spaces.
+		for _, tok := range word {
+			w.writeEscape(tok.text)
+		}
+		return spaces
+	}
+
+	if w.col > w.start && w.col+spaces+wordWidth > w.width {
+		w.breakLine(true)
+	} else {
+		w.buf.WriteString(strings.Repeat(" ", spaces))
+		w.col += spaces
+	}
+
+	for _, tok := range word {
+		if tok.width == 0 {
+			w.writeEscape(tok.text)
+			continue
+		}
+		w.writeText(tok.text, tok.width)
+	}
+
+	return 0
+}
+
+func (w *lineWriter) hardWrap(str string) {
+	for i := 0; i < len(str); {
+		switch str[i] {
+		case _esc:
+			n := escapeLen(str[i:])
+			w.writeEscape(str[i : i+n])
+			i += n
+		case '\n':
+			w.breakLine(false)
+			i++
+		default:
+			r, n := utf8.DecodeRuneInString(str[i:])
+			w.writeText(str[i:i+n], RuneWidth(r))
+			i += n
+		}
+	}
+}
+
+func (w *lineWriter) writeEscape(seq string) {
+	w.buf.WriteString(seq)
+	w.state.update(seq)
+}
+
+func (w *lineWriter) writeText(text string, width int) {
+	if w.width > 0 && w.col > w.start && w.col+width > w.width {
+		w.breakLine(true)
+	}
+	w.buf.WriteString(text)
+	w.col += width
+}
+
+func (w *lineWriter) breakLine(continuation bool) {
+	w.buf.WriteString(w.state.close())
+	w.lines = append(w.lines, w.buf.String())
+	w.buf.Reset()
+	w.col = 0
+
+	if continuation {
+		w.buf.WriteString(w.opts.Indent)
+		w.col = w.indentWidth
+	}
+	w.start = w.col
+
+	w.buf.WriteString(w.state.open())
+}
+
+func (w *lineWriter) finish() []string {
+	return append(w.lines, w.buf.String())
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2024 Matt Way
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+// IN THE THE SOFTWARE.
+
+package color
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordWrap(t *testing.T) {
+	var (
+		red   = FgRed.String()
+		reset = Reset.String()
+	)
+
+	cases := []struct {
+		name  string
+		give  string
+		width int
+		opts  []WrapOption
+		want  string
+	}{
+		{
+			name:  "plain",
+			give:  "the quick brown fox",
+			width: 10,
+			want:  "the quick\nbrown fox",
+		},
+		{
+			name:  "disabled",
+			give:  "the quick brown fox",
+			width: 0,
+			want:  "the quick brown fox",
+		},
+		{
+			name:  "styled",
+			give:  red + "the quick brown fox" + reset,
+			width: 10,
+			want:  red + "the quick" + reset + "\n" + red + "brown fox" + reset,
+		},
+		{
+			name:  "escapes do not count",
+			give:  "the " + red + "quick" + reset + " brown",
+			width: 9,
+			want:  "the " + red + "quick" + reset + "\nbrown",
+		},
+		{
+			name:  "long word",
+			give:  "a abcdefghij",
+			width: 4,
+			want:  "a\nabcd\nefgh\nij",
+		},
+		{
+			name:  "indent",
+			give:  red + "aa bb cc" + reset,
+			width: 5,
+			opts:  []WrapOption{WithIndent("  ")},
+			want:  red + "aa bb" + reset + "\n  " + red + "cc" + reset,
+		},
+		{
+			name:  "newlines",
+			give:  red + "aa\nbb cc" + reset,
+			width: 2,
+			opts:  []WrapOption{WithIndent(" ")},
+			want: red + "aa" + reset + "\n" + red + "bb" + reset + "\n " +
+				red + "c" + reset + "\n " + red + "c" + reset,
+		},
+		{
+			name:  "wide runes",
+			give:  "世界 世界",
+			width: 4,
+			want:  "世界\n世界",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, WordWrap(tt.give, tt.width, tt.opts...))
+		})
+	}
+}
+
+func TestHardWrap(t *testing.T) {
+	var (
+		red   = FgRed.String()
+		reset = Reset.String()
+	)
+
+	require.Equal(t, "the q\nuick \nbrown", HardWrap("the quick brown", 5))
+	require.Equal(
+		t,
+		"ab"+red+"c"+reset+"\n> "+red+"d"+reset+"\n> e",
+		HardWrap("ab"+red+"cd"+reset+"e", 3, WithIndent("> ")),
+	)
+	require.Equal(t, "abc", HardWrap("abc", -1))
+}
+
+func TestSplitLines(t *testing.T) {
+	var (
+		red   = FgRed.String()
+		bold  = Bold.String()
+		reset = Reset.String()
+	)
+
+	require.Equal(t, []string{"a", "b"}, SplitLines("a\nb"))
+	require.Equal(
+		t,
+		[]string{
+			red + "a" + bold + reset,
+			"\x1b[31;1mb" + reset,
+			"c",
+		},
+		SplitLines(red+"a"+bold+"\nb"+reset+"\nc"),
+	)
+}
+
+func TestWrapOptions(t *testing.T) {
+	opts := DefaultWrapOptions().With(WithIndent("x"))
+	require.Equal(t, "x", opts.Indent)
+	require.Equal(t, "x", DefaultWrapOptions().With(opts).Indent)
+}